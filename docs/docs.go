@@ -28,6 +28,94 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/export/gpkg": {
+            "get": {
+                "description": "Export every geospatial layer of each geometry file of a RAS model to a GeoPackage given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Export geospatial data as a GeoPackage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination EPSG code e.g. 2277, or none to keep the model projection",
+                        "name": "epsg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination WKT or PROJ string",
+                        "name": "crs",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/forcingdata": {
+            "get": {
+                "description": "forcing data from a RAS model given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract forcing data from flow files",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/geospatialdata": {
             "get": {
                 "description": "Extract geospatial data from a RAS model given an s3 key",
@@ -48,6 +136,36 @@ var doc = `{
                         "name": "definition_file",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or geojson",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "geojson layer e.g. XS",
+                        "name": "layer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "geojson geometry file name or extension e.g. .g01",
+                        "name": "geom_file",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination EPSG code e.g. 2277, or none to keep the model projection, geojson only supports 4326",
+                        "name": "epsg",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "destination WKT or PROJ string, geojson only supports EPSG:4326",
+                        "name": "crs",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -244,6 +362,47 @@ var doc = `{
                 }
             }
         },
+        "/network": {
+            "get": {
+                "description": "Build the river network of each geometry file of a RAS model and check its cross section stations given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Build RAS model river network",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/tools.Network"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/ping": {
             "get": {
                 "description": "Check which services are operational",
@@ -266,6 +425,88 @@ var doc = `{
                     }
                 }
             }
+        },
+        "/results": {
+            "get": {
+                "description": "Summarize the plan HDF output of a RAS model given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Summarize RAS model results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/tools.PlanResults"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/runlog": {
+            "get": {
+                "description": "Extract warnings, errors and volume accounting errors from the computation logs of a RAS model given an s3 key",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCAT"
+                ],
+                "summary": "Extract RAS model run diagnostics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj",
+                        "name": "definition_file",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/tools.RunLog"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SimpleResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -385,6 +626,81 @@ var doc = `{
                 }
             }
         },
+        "tools.Network": {
+            "type": "object",
+            "properties": {
+                "dangling_reaches": {
+                    "description": "reaches not connected to any junction",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reaches": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/tools.NetworkReach"
+                    }
+                },
+                "unknown_reaches": {
+                    "description": "reaches referenced by junctions but not found in the geometry file",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "tools.NetworkReach": {
+            "type": "object",
+            "properties": {
+                "cross_sections": {
+                    "description": "river stations ordered from upstream to downstream",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "downstream": {
+                    "description": "reaches this reach flows into",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "downstream_junction": {
+                    "type": "string"
+                },
+                "duplicate_stations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "out_of_order_stations": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                },
+                "upstream": {
+                    "description": "reaches flowing into this reach",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "upstream_junction": {
+                    "type": "string"
+                }
+            }
+        },
         "tools.OutputFiles": {
             "type": "object",
             "properties": {
@@ -412,6 +728,91 @@ var doc = `{
                 }
             }
         },
+        "tools.PlanResults": {
+            "type": "object",
+            "properties": {
+                "2d_areas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/tools.TwoDAreaResult"
+                    }
+                },
+                "computation_time": {
+                    "type": "string"
+                },
+                "cross_sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.XSResult"
+                    }
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "plan_file": {
+                    "type": "string"
+                },
+                "run_time_window": {
+                    "type": "string"
+                },
+                "solution": {
+                    "type": "string"
+                },
+                "volume_accounting": {
+                    "$ref": "#/definitions/tools.VolumeAccounting"
+                }
+            }
+        },
+        "tools.RunLog": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "description": "number of messages of each type",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "file": {
+                    "type": "string"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tools.RunMessage"
+                    }
+                },
+                "volume_error_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "tools.RunMessage": {
+            "type": "object",
+            "properties": {
+                "2d_area": {
+                    "type": "string"
+                },
+                "cell": {
+                    "type": "integer"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                },
+                "river_station": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
         "tools.SupplementalFiles": {
             "type": "object",
             "properties": {
@@ -430,6 +831,78 @@ var doc = `{
                     "type": "object"
                 }
             }
+        },
+        "tools.TwoDAreaResult": {
+            "type": "object",
+            "properties": {
+                "max_depth": {
+                    "type": "number"
+                },
+                "max_velocity": {
+                    "type": "number"
+                },
+                "max_water_surface": {
+                    "type": "number"
+                },
+                "mean_max_depth": {
+                    "description": "mean of the maximum depth of wet cells",
+                    "type": "number"
+                },
+                "mean_max_velocity": {
+                    "description": "mean of the maximum velocity of all faces",
+                    "type": "number"
+                },
+                "num_cells": {
+                    "type": "integer"
+                },
+                "num_wet_cells": {
+                    "type": "integer"
+                }
+            }
+        },
+        "tools.VolumeAccounting": {
+            "type": "object",
+            "properties": {
+                "ending_volume": {
+                    "type": "number"
+                },
+                "error": {
+                    "type": "number"
+                },
+                "error_percent": {
+                    "type": "number"
+                },
+                "inflow_volume": {
+                    "type": "number"
+                },
+                "outflow_volume": {
+                    "type": "number"
+                },
+                "starting_volume": {
+                    "type": "number"
+                }
+            }
+        },
+        "tools.XSResult": {
+            "type": "object",
+            "properties": {
+                "max_water_surface": {
+                    "type": "number"
+                },
+                "reach": {
+                    "type": "string"
+                },
+                "river": {
+                    "type": "string"
+                },
+                "river_station": {
+                    "type": "string"
+                },
+                "time_of_max_water_surface": {
+                    "description": "days since simulation start",
+                    "type": "number"
+                }
+            }
         }
     }
 }`
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dewberry/mcat-ras/config"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+	"github.com/labstack/echo/v4"
+)
+
+// ExportGeoPackage godoc
+// @Summary Export geospatial data as a GeoPackage
+// @Description Export every geospatial layer of each geometry file of a RAS model to a GeoPackage given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce octet-stream
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param epsg query string false "destination EPSG code e.g. 2277, or none to keep the model projection"
+// @Param crs query string false "destination WKT or PROJ string"
+// @Success 200 {file} file
+// @Failure 500 {object} SimpleResponse
+// @Router /export/gpkg [get]
+func ExportGeoPackage(ac *config.APIConfig) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+		if definitionFile == "" {
+			return c.JSON(http.StatusBadRequest, "Missing query parameter: `definition_file`")
+		}
+
+		destinationCRS, err := requestCRS(c, ac.DestinationCRS)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+
+		if !isAModel(ac.FileStore, definitionFile) {
+			return c.JSON(http.StatusBadRequest, definitionFile+" is not a valid RAS prj file.")
+		}
+
+		if !isGeospatial(definitionFile, *ac.FileStore) {
+			return c.JSON(http.StatusBadRequest, definitionFile+" is not geospatial.")
+		}
+
+		data, err := geospatialData(definitionFile, ac.FileStore, destinationCRS)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+		}
+
+		tempDir, err := os.MkdirTemp("", "mcat-ras-gpkg")
+		if err != nil {
+			err = errors.Wrap(err, 0)
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+		}
+		defer os.RemoveAll(tempDir)
+
+		modelName := strings.TrimSuffix(filepath.Base(definitionFile), ".prj")
+		gpkgFile := filepath.Join(tempDir, modelName+".gpkg")
+		if err := data.WriteGeoPackage(gpkgFile); err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+		}
+
+		return c.Attachment(gpkgFile, modelName+".gpkg")
+	}
+}
@@ -47,8 +47,9 @@ func ForcingData(ac *config.APIConfig) echo.HandlerFunc {
 
 func forcingData(definitionFile string, fs *filestore.FileStore) (tools.ForcingData, error) {
 	fd := tools.ForcingData{
-		Steady:   make(map[string]tools.SteadyData),
-		Unsteady: make(map[string]tools.UnsteadyData),
+		Steady:        make(map[string]tools.SteadyData),
+		QuasiUnsteady: make(map[string]tools.QuasiUnsteadyData),
+		Unsteady:      make(map[string]tools.UnsteadyData),
 	}
 
 	mfiles, err := modFiles(definitionFile, *fs)
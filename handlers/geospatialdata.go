@@ -1,10 +1,10 @@
 package handlers
 
 import (
-	"bufio"
 	"fmt"
 	"net/http"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/Dewberry/mcat-ras/config"
@@ -23,6 +23,11 @@ import (
 // @Accept json
 // @Produce json
 // @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Param format query string false "json or geojson"
+// @Param layer query string false "geojson layer e.g. XS"
+// @Param geom_file query string false "geojson geometry file name or extension e.g. .g01"
+// @Param epsg query string false "destination EPSG code e.g. 2277, or none to keep the model projection, geojson only supports 4326"
+// @Param crs query string false "destination WKT or PROJ string, geojson only supports EPSG:4326"
 // @Success 200 {object} interface{}
 // @Failure 500 {object} SimpleResponse
 // @Router /geospatialdata [get]
@@ -34,6 +39,31 @@ func GeospatialData(ac *config.APIConfig) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, "Missing query parameter: `definition_file`")
 		}
 
+		format := c.QueryParam("format")
+		if format != "" && format != "json" && format != "geojson" {
+			return c.JSON(http.StatusBadRequest, "Invalid query parameter: `format` must be json or geojson")
+		}
+
+		layer := c.QueryParam("layer")
+		if layer != "" && !tools.IsLayer(layer) {
+			return c.JSON(http.StatusBadRequest, layer+" is not a valid layer.")
+		}
+
+		// RFC 7946 GeoJSON coordinates are always WGS 84 longitude and latitude
+		defaultCRS := ac.DestinationCRS
+		if format == "geojson" {
+			defaultCRS = 4326
+		}
+
+		destinationCRS, err := requestCRS(c, defaultCRS)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, err.Error())
+		}
+
+		if format == "geojson" && destinationCRS != "EPSG:4326" {
+			return c.JSON(http.StatusBadRequest, "Invalid query parameter: `format` geojson only supports `epsg` 4326")
+		}
+
 		if !isAModel(ac.FileStore, definitionFile) {
 			return c.JSON(http.StatusBadRequest, definitionFile+" is not a valid RAS prj file.")
 		}
@@ -42,28 +72,72 @@ func GeospatialData(ac *config.APIConfig) echo.HandlerFunc {
 			return c.JSON(http.StatusBadRequest, definitionFile+" is not geospatial.")
 		}
 
-		data, err := geospatialData(definitionFile, ac.FileStore, ac.DestinationCRS)
+		data, err := geospatialData(definitionFile, ac.FileStore, destinationCRS)
 		if err != nil {
 			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
 		}
 
+		if format == "geojson" {
+			fc, err := data.FeatureCollection(layer, c.QueryParam("geom_file"))
+			if err != nil {
+				return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+			}
+			return c.JSON(http.StatusOK, fc)
+		}
+
 		return c.JSON(http.StatusOK, data)
 	}
 }
 
-func geospatialData(definitionFile string, fs *filestore.FileStore, destinationCRS int) (tools.GeoData, error) {
-	gd := tools.GeoData{Features: make(map[string]tools.Features), Georeference: destinationCRS}
+// Get the destination coordinate reference system of a geospatial request.
+// epsg accepts an EPSG code or none to keep the model projection, crs accepts a WKT or PROJ string
+func requestCRS(c echo.Context, defaultCRS int) (string, error) {
+	epsg, crs := c.QueryParam("epsg"), c.QueryParam("crs")
+
+	switch {
+	case epsg != "" && crs != "":
+		return "", errors.New("Only one of the query parameters `epsg` or `crs` can be provided")
+
+	case epsg == "none" || crs == "none":
+		return "none", nil
+
+	case epsg != "":
+		code, err := strconv.Atoi(strings.TrimPrefix(strings.ToUpper(epsg), "EPSG:"))
+		if err != nil {
+			return "", errors.New("Invalid query parameter: `epsg` must be an EPSG code or none")
+		}
+		spRef := gdal.CreateSpatialReference("")
+		defer spRef.Destroy()
+		if err := spRef.FromEPSG(code); err != nil {
+			return "", errors.Errorf("Invalid query parameter: `epsg` %d is not a known EPSG code", code)
+		}
+		return fmt.Sprintf("EPSG:%d", code), nil
+
+	case crs != "":
+		spRef := gdal.CreateSpatialReference("")
+		defer spRef.Destroy()
+		if err := spRef.SetFromUserInput(crs); err != nil {
+			return "", errors.New("Invalid query parameter: `crs` must be a WKT or PROJ string")
+		}
+		return crs, nil
+	}
+
+	return fmt.Sprintf("EPSG:%d", defaultCRS), nil
+}
+
+func geospatialData(definitionFile string, fs *filestore.FileStore, destinationCRS string) (tools.GeoData, error) {
+	gd := tools.GeoData{}
 
 	mfiles, err := modFiles(definitionFile, *fs)
 	if err != nil {
 		return gd, errors.Wrap(err, 0)
 	}
 
-	projecFile := strings.TrimSuffix(definitionFile, ".prj") + ".projection"
-	proj, err := getProjection(*fs, projecFile)
+	proj, _, err := tools.ResolveProjection(definitionFile, *fs)
 	if err != nil {
 		return gd, errors.Wrap(err, 0)
 	}
+	gd = tools.NewGeoData(proj, destinationCRS)
 
 	for _, fp := range mfiles {
 
@@ -73,7 +147,7 @@ func geospatialData(definitionFile string, fs *filestore.FileStore, destinationC
 
 		case tools.RasRE.Geom.MatchString(ext):
 
-			if err := tools.GetGeospatialData(&gd, *fs, fp, proj, destinationCRS); err != nil {
+			if err := tools.GetGeospatialData(&gd, *fs, fp, mfiles, proj, destinationCRS); err != nil {
 				return gd, errors.Wrap(err, 0)
 			}
 
@@ -82,20 +156,3 @@ func geospatialData(definitionFile string, fs *filestore.FileStore, destinationC
 
 	return gd, nil
 }
-
-func getProjection(fs filestore.FileStore, fn string) (string, error) {
-
-	f, err := fs.GetObject(fn)
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
-
-	sc := bufio.NewScanner(f)
-	sc.Scan()
-	line := sc.Text()
-
-	sourceSpRef := gdal.CreateSpatialReference(line)
-
-	return line, sourceSpRef.Validate()
-}
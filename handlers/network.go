@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	ras "github.com/Dewberry/mcat-ras/tools"
+
+	"github.com/USACE/filestore"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+	"github.com/labstack/echo/v4"
+)
+
+// Network godoc
+// @Summary Build RAS model river network
+// @Description Build the river network of each geometry file of a RAS model and check its cross section stations given an s3 key
+// @Tags MCAT
+// @Accept json
+// @Produce json
+// @Param definition_file query string true "/models/ras/CHURCH HOUSE GULLY/CHURCH HOUSE GULLY.prj"
+// @Success 200 {object} map[string]ras.Network
+// @Failure 500 {object} SimpleResponse
+// @Router /network [get]
+func Network(fs *filestore.FileStore) echo.HandlerFunc {
+	return func(c echo.Context) error {
+
+		definitionFile := c.QueryParam("definition_file")
+		if definitionFile == "" {
+			return c.JSON(http.StatusBadRequest, "Missing query parameter: `definition_file`")
+		}
+
+		if !isAModel(fs, definitionFile) {
+			return c.JSON(http.StatusBadRequest, definitionFile+" is not a valid RAS prj file.")
+		}
+
+		rm, err := ras.NewRasModel(definitionFile, *fs)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+		}
+
+		network, err := rm.Network()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, SimpleResponse{http.StatusInternalServerError, fmt.Sprintf("Go error encountered: %v", err.Error()), err.(*errors.Error).ErrorStack()})
+		}
+
+		return c.JSON(http.StatusOK, network)
+	}
+}
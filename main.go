@@ -38,6 +38,10 @@ func main() {
 	e.GET("/isgeospatial", handlers.IsGeospatial(appConfig.FileStore))
 	e.GET("/geospatialdata", handlers.GeospatialData(appConfig))
 	e.GET("/forcingdata", handlers.ForcingData(appConfig))
+	e.GET("/results", handlers.Results(appConfig.FileStore))
+	e.GET("/runlog", handlers.RunLog(appConfig.FileStore))
+	e.GET("/network", handlers.Network(appConfig.FileStore))
+	e.GET("/export/gpkg", handlers.ExportGeoPackage(appConfig))
 
 	// pgdb endpoints
 	e.POST("/upsert/model", pgdb.UpsertRasModel(appConfig, dbConfig))
@@ -35,6 +35,12 @@ SELECT
     (metadata ->> 'GeomFile') AS geom_file,
     (metadata ->> 'FlowFile') AS flow_file,
     (metadata ->> 'FlowRegime') AS flow_regime,
+    (metadata ->> 'SimulationStart') AS simulation_start,
+    (metadata ->> 'SimulationEnd') AS simulation_end,
+    (metadata ->> 'ComputationInterval') AS computation_interval,
+    (metadata ->> 'OutputInterval') AS output_interval,
+    (metadata ->> 'MappingInterval') AS mapping_interval,
+    (metadata ->> 'FrictionSlopeMethod') AS friction_slope_method,
     (metadata ->> 'Path') AS s3_key
 FROM plan_files
 WITH DATA;
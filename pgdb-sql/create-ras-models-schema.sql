@@ -133,6 +133,45 @@ CREATE INDEX IF NOT EXISTS ras_hydraulic_structures_geometry_file_id_idx ON mode
 -- Create index on geometry
 CREATE INDEX IF NOT EXISTS ras_hydraulic_structure_geom_idx ON models.ras_hydraulic_structures USING GIST (geom);
 
+/*---------------------------------------------------------------------------*/
+-- Create models.ras_structures table
+/*---------------------------------------------------------------------------*/
+CREATE TABLE IF NOT EXISTS models.ras_structures(
+       structure_id SERIAL PRIMARY KEY,
+       river_id INTEGER REFERENCES models.ras_rivers ON UPDATE CASCADE ON DELETE CASCADE,
+       structure_station DECIMAL NOT NULL,
+       structure_type TEXT NOT NULL,
+       structure_name TEXT,
+       attributes JSON,
+       geom GEOMETRY(MultiPoint, 4326),
+       CONSTRAINT ras_structures_river_id_structure_station UNIQUE (river_id, structure_station)
+);
+
+-- Create index on foreign key
+CREATE INDEX IF NOT EXISTS ras_structures_river_id_idx ON models.ras_structures (river_id);
+
+-- Create index on geometry
+CREATE INDEX IF NOT EXISTS ras_structures_geom_idx ON models.ras_structures USING GIST (geom);
+
+/*---------------------------------------------------------------------------*/
+-- Create models.ras_junctions table
+/*---------------------------------------------------------------------------*/
+CREATE TABLE IF NOT EXISTS models.ras_junctions(
+       junction_id SERIAL PRIMARY KEY,
+       geometry_file_id INTEGER REFERENCES models.ras_geometry_files ON UPDATE CASCADE ON DELETE CASCADE,
+       junction_name TEXT NOT NULL,
+       up_reaches JSON,
+       dn_reaches JSON,
+       geom GEOMETRY(MultiPoint, 4326),
+       CONSTRAINT ras_junctions_geometry_file_id_name_uniq UNIQUE (geometry_file_id, junction_name)
+);
+
+-- Create index on foreign key
+CREATE INDEX IF NOT EXISTS ras_junctions_geometry_file_id_idx ON models.ras_junctions (geometry_file_id);
+
+-- Create index on geometry
+CREATE INDEX IF NOT EXISTS ras_junctions_geom_idx ON models.ras_junctions USING GIST (geom);
+
 /*---------------------------------------------------------------------------*/
 -- Create models.ras_connections table
 /*---------------------------------------------------------------------------*/
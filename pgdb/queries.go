@@ -103,6 +103,47 @@ var (
 			geom = ST_GeomFromWKB($3, 4326);
 	`
 
+	upsertStructuresSQL string = `
+		INSERT INTO models.ras_structures (
+			river_id, 
+			structure_station, 
+			structure_type, 
+			structure_name, 
+			attributes, 
+			geom
+			) 
+		VALUES ($1, $2, $3, $4, $5, ST_GeomFromWKB($6, 4326))
+		ON CONFLICT (
+			river_id,
+			structure_station
+		)
+		DO UPDATE SET 
+			river_id = $1, 
+			structure_station = $2, 
+			structure_type = $3, 
+			structure_name = $4, 
+			attributes = $5, 
+			geom = ST_GeomFromWKB($6, 4326);
+	`
+
+	upsertJunctionsSQL string = `
+		INSERT INTO models.ras_junctions (
+			geometry_file_id, 
+			junction_name, 
+			up_reaches,
+			dn_reaches,
+			geom
+			) 
+			VALUES ($1, $2, $3, $4, ST_GeomFromWKB($5, 4326))
+		ON CONFLICT (geometry_file_id, junction_name)
+		DO UPDATE SET 
+			geometry_file_id = $1, 
+			junction_name = $2,
+			up_reaches = $3,
+			dn_reaches = $4,
+			geom = ST_GeomFromWKB($5, 4326);
+	`
+
 	upsertAreasSQL string = `
 		INSERT INTO models.ras_areas (
 			geometry_file_id, 
@@ -198,7 +239,9 @@ var vacuumQuery []string = []string{"VACUUM ANALYZE models.ras;",
 	"VACUUM ANALYZE models.ras_breaklines;",
 	"VACUUM ANALYZE models.ras_bclines;",
 	"VACUUM ANALYZE models.ras_connections;",
-	"VACUUM ANALYZE models.ras_hydraulic_structures;"}
+	"VACUUM ANALYZE models.ras_hydraulic_structures;",
+	"VACUUM ANALYZE models.ras_structures;",
+	"VACUUM ANALYZE models.ras_junctions;"}
 
 // RefreshViewsQuery ...
 var refreshViewsQuery []string = []string{"REFRESH MATERIALIZED VIEW models.ras_projects_metadata;",
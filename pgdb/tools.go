@@ -45,7 +45,7 @@ func upsertModel(tx *sqlx.Tx, rm *ras.RasModel, definitionFile string, collectio
 	projFileName := filepath.Base(definitionFile)
 	modelName := strings.TrimSuffix(projFileName, filepath.Ext(projFileName))
 
-	etlMetaRaw := ETLMetaData{ModelName: modelName, SourcePath: definitionFile}
+	etlMetaRaw := ETLMetaData{ModelName: modelName, SourcePath: definitionFile, ProjectionSourcePath: rm.Metadata.ProjectionSource}
 
 	etlMeta, err := json.Marshal(etlMetaRaw)
 	if err != nil {
@@ -145,7 +145,8 @@ func upsertModelGeometry(definitionFile string, ac *config.APIConfig, db *sqlx.D
 
 	if rm.IsGeospatial() {
 
-		geodata, err := rm.GeospatialData(ac.DestinationCRS)
+		// geometries are stored with srid 4326
+		geodata, err := rm.GeospatialData(fmt.Sprintf("EPSG:%d", ac.DestinationCRS))
 		if err != nil {
 			return errors.Wrap(err, 0)
 		}
@@ -225,6 +226,45 @@ func upsertModelGeometry(definitionFile string, ac *config.APIConfig, db *sqlx.D
 				}
 			}
 
+			// Add all hydraulic structures
+			for _, structure := range features.HydraulicStructures {
+				riverID := riverIDMap[structure.Fields["RiverReachName"].(string)]
+				structureStation, err := strconv.ParseFloat(structure.FeatureName, 64)
+				if err != nil {
+					log.Println("Structures", geometryFile.FileExt, "|", err)
+					return errors.Wrap(err, 0)
+				}
+
+				attributes, err := json.Marshal(structure.Fields)
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+
+				_, err = tx.Exec(upsertStructuresSQL, riverID, structureStation, structure.Fields["Type"], structure.Fields["Name"], attributes, structure.Geometry)
+				if err != nil {
+					log.Println("Structures", geometryFile.FileExt, "|", err)
+					return errors.Wrap(err, 0)
+				}
+			}
+
+			// Add all junctions
+			for _, junct := range features.Junctions {
+				upReaches, err := json.Marshal(junct.Fields["Up Reaches"])
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+				dnReaches, err := json.Marshal(junct.Fields["Dn Reaches"])
+				if err != nil {
+					return errors.Wrap(err, 0)
+				}
+
+				_, err = tx.Exec(upsertJunctionsSQL, geometryFileID, junct.FeatureName, upReaches, dnReaches, junct.Geometry)
+				if err != nil {
+					log.Println("Junctions", geometryFile.FileExt, "|", err)
+					return errors.Wrap(err, 0)
+				}
+			}
+
 			// Create Dynamic container to map bclines with areas
 			areasIDMap := make(map[string]int, (len(features.StorageAreas) + len(features.TwoDAreas)))
 
@@ -3,6 +3,7 @@ package tools
 import (
 	"bufio"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -10,20 +11,64 @@ import (
 )
 
 type StorageArea struct {
-	NumBCLines int      `json:"Num BC Lines"`
-	BCLines    []string `json:"BC Lines"`
+	NumBCLines  int          `json:"Num BC Lines"`
+	BCLines     []string     `json:"BC Lines"`
+	ElevVolume  [][2]float64 `json:"Elevation Volume"` // elevation, volume pairs
+	Elevation   maxMinPairs  `json:"Elevations"`
+	TotalVolume float64      `json:"Total Volume"` // volume at the maximum elevation of the curve
 }
 
 type TwoDArea struct {
-	NumCells   int      `json:"Num Mesh Cells"`
-	NumBCLines int      `json:"Num BC Lines"`
-	BCLines    []string `json:"BC Lines"`
+	NumCells          int              `json:"Num Mesh Cells"`
+	NumBCLines        int              `json:"Num BC Lines"`
+	BCLines           []string         `json:"BC Lines"`
+	CellSize          cellSize         `json:"Nominal Cell Size"`
+	Mannings          float64          `json:"Default Mannings n"`
+	FaceTolerances    faceTolerances   `json:"Face Tolerances"`
+	LandCover         string           `json:"Land Cover Layer"` // land cover layer of the geometry in the .rasmap
+	ManningsRegions   []manningsRegion `json:"Mannings n Override Regions"`
+	RefinementRegions []meshRegion     `json:"Refinement Regions"`
+	BreakLines        []meshRegion     `json:"Break Lines"`
+	perimeter         [][2]float64
+}
+
+// Store nominal cell spacing used to generate 2D mesh points
+type cellSize struct {
+	DX float64
+	DY float64
+}
+
+// Store 2D area cell and face property table tolerances
+type faceTolerances struct {
+	CellVolume        float64 `json:"Cell Volume Filter"`
+	FaceProfile       float64 `json:"Face Profile Filter"`
+	FaceAreaElevation float64 `json:"Face Area Elevation Filter"`
+	ConveyanceRatio   float64 `json:"Face Conveyance Ratio"`
+}
+
+// Store cell spacing parameters of Break Lines and Refinement Regions
+type meshRegion struct {
+	Name             string
+	CellSizeMin      float64 `json:"Cell Size Min"`
+	CellSizeMax      float64 `json:"Cell Size Max"`
+	NearRepeats      int     `json:"Near Repeats"`
+	ProtectionRadius float64 `json:"Protection Radius"`
+	xyPairs          [][2]float64
+}
+
+// Store land cover Manning's n values overridden within a region
+type manningsRegion struct {
+	Name     string
+	Mannings map[string]float64 `json:"Mannings n"` // land cover name: n value
+	xyPairs  [][2]float64
 }
 
 // Extract Storage and 2D Areas Data
 func getAreasData(rm *RasModel, fn string, i int) (string, interface{}, error) {
 	var name, is2D string
 	var numCells int
+	var elevVolume, perimeter [][2]float64
+	var twoDArea TwoDArea
 
 	f, err := rm.FileStore.GetObject(fn)
 	if err != nil {
@@ -51,32 +96,215 @@ areaLoop:
 					return "", nil, errors.New(fmt.Sprintf("Cannot determine if area is storage area or 2D area at line '%v' of %v", line, fn))
 				}
 
+			case strings.HasPrefix(line, "Storage Area Surface Line="):
+				perimeter, err = getDataPairsfromTextBlock("Storage Area Surface Line=", aSc, 32, 16)
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, "Storage Area Point Generation Data="):
+				// e.g. 'Storage Area Point Generation Data=,,100,100' (x origin, y origin, dx, dy)
+				lineData := strings.Split(rightofEquals(line), ",")
+				if len(lineData) < 4 {
+					continue
+				}
+				dx, err := stringtoFloat(lineData[2])
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+				dy, err := stringtoFloat(lineData[3])
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+				twoDArea.CellSize = cellSize{DX: dx, DY: dy}
+
+			case strings.HasPrefix(line, "Storage Area Mannings="):
+				twoDArea.Mannings, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, "2D Cell Volume Filter Tolerance="):
+				twoDArea.FaceTolerances.CellVolume, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, "2D Face Profile Filter Tolerance="):
+				twoDArea.FaceTolerances.FaceProfile, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, "2D Face Area Elevation Profile Filter Tolerance="):
+				twoDArea.FaceTolerances.FaceAreaElevation, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, "2D Face Area Elevation Conveyance Ratio="):
+				twoDArea.FaceTolerances.ConveyanceRatio, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
+				// last property of a 2D area
+				break areaLoop
+
 			case strings.HasPrefix(line, "Storage Area 2D Points="):
 				numCells, err = strconv.Atoi(rightofEquals(line))
 				if err != nil {
 					return "", nil, errors.Wrap(err, 0)
 				}
 
-			case strings.HasPrefix(line, "2D Face Area "):
-				break areaLoop
+			case strings.HasPrefix(line, "Storage Area Vol Elev="):
+				elevVolume, err = getDataPairsfromTextBlock("Storage Area Vol Elev=", aSc, 80, 8)
+				if err != nil {
+					return "", nil, errors.Wrap(err, 0)
+				}
 
-			case strings.HasPrefix(line, "Storage Area="):
-				// guard to make sure new Storage Area don't overwrite previous values
+			case strings.HasPrefix(line, "Storage Area="), strings.HasPrefix(line, "Connection="),
+				strings.HasPrefix(line, "BreakLine Name="), strings.HasPrefix(line, "BC Line Name="):
+				// guard to make sure new elements don't overwrite previous values
 				break areaLoop
 			}
 		}
 	}
 	if is2D == "0" {
-		area := StorageArea{}
-		return name, area, nil
-	} else {
-		area := TwoDArea{
-			NumCells: numCells,
+		area := StorageArea{ElevVolume: elevVolume}
+		if len(elevVolume) > 0 {
+			area.Elevation = maxMinPairs{Max: elevVolume[0][0], Min: elevVolume[0][0]}
+			for _, pair := range elevVolume {
+				area.Elevation.Max = math.Max(area.Elevation.Max, pair[0])
+				area.Elevation.Min = math.Min(area.Elevation.Min, pair[0])
+				area.TotalVolume = math.Max(area.TotalVolume, pair[1])
+			}
 		}
 		return name, area, nil
 	}
 
-	return "", nil, errors.New(fmt.Sprintf("Failed to parse storage area at geom file line number %v of %v", i, fn))
+	twoDArea.NumCells = numCells
+	twoDArea.perimeter = perimeter
+	return name, twoDArea, nil
+}
+
+// Extract cell spacing parameters of Break Lines and Refinement Regions
+// keyword is either 'BreakLine' or 'Refinement Region' e.g. 'BreakLine CellSize Min=50'
+func getMeshRegionData(rm *RasModel, fn string, i int, keyword string) (meshRegion, error) {
+	region := meshRegion{}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return region, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	rSc := bufio.NewScanner(f)
+	var ri int
+
+	for rSc.Scan() {
+		ri++
+		if ri == i {
+			region.Name = rightofEquals(rSc.Text())
+
+		} else if ri > i {
+			line := rSc.Text()
+			switch {
+
+			case strings.HasPrefix(line, keyword+" CellSize Min="):
+				region.CellSizeMin, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, keyword+" CellSize Max="):
+				region.CellSizeMax, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+
+			case strings.HasPrefix(line, keyword+" Near Repeats="):
+				if rightofEquals(line) != "" {
+					region.NearRepeats, err = strconv.Atoi(rightofEquals(line))
+					if err != nil {
+						return region, errors.Wrap(err, 0)
+					}
+				}
+
+			case strings.HasPrefix(line, keyword+" Protection Radius="):
+				region.ProtectionRadius, err = stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+
+			// geometry is the last block of a Break Line or Refinement Region
+			case strings.HasPrefix(line, keyword+" Polyline="), strings.HasPrefix(line, keyword+" Polygon="):
+				region.xyPairs, err = getDataPairsfromTextBlock(keyword+" Poly", rSc, 64, 16)
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+				return region, nil
+
+			case strings.HasPrefix(line, keyword+" Name="):
+				return region, errors.New(fmt.Sprintf("Failed to parse %s at geom file line number %v of %v", keyword, i, fn))
+			}
+		}
+	}
+	return region, errors.New(fmt.Sprintf("Failed to parse %s at geom file line number %v of %v", keyword, i, fn))
+}
+
+// Extract land cover Manning's n override regions
+// e.g. 'LCMann Region Table= 2' followed by 'land cover name,n value' lines
+func getManningsRegionData(rm *RasModel, fn string, i int) (manningsRegion, error) {
+	region := manningsRegion{Mannings: make(map[string]float64)}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return region, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	mSc := bufio.NewScanner(f)
+	var mi int
+
+	for mSc.Scan() {
+		mi++
+		if mi == i {
+			region.Name = rightofEquals(mSc.Text())
+
+		} else if mi > i {
+			line := mSc.Text()
+			switch {
+
+			case strings.HasPrefix(line, "LCMann Region Table="):
+				nValues, err := strconv.Atoi(rightofEquals(line))
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+				for v := 0; v < nValues && mSc.Scan(); v++ {
+					lineData := strings.Split(mSc.Text(), ",")
+					if len(lineData) < 2 {
+						continue
+					}
+					n, err := stringtoFloat(lineData[len(lineData)-1])
+					if err != nil {
+						return region, errors.Wrap(err, 0)
+					}
+					region.Mannings[strings.TrimSpace(strings.Join(lineData[:len(lineData)-1], ","))] = n
+				}
+
+			case strings.HasPrefix(line, "LCMann Region Polygon="):
+				region.xyPairs, err = getDataPairsfromTextBlock("LCMann Region Polygon=", mSc, 64, 16)
+				if err != nil {
+					return region, errors.Wrap(err, 0)
+				}
+				return region, nil
+
+			case strings.HasPrefix(line, "LCMann Region Name="):
+				return region, errors.New(fmt.Sprintf("Failed to parse Manning's n region at geom file line number %v of %v", i, fn))
+			}
+		}
+	}
+	return region, errors.New(fmt.Sprintf("Failed to parse Manning's n region at geom file line number %v of %v", i, fn))
 }
 
 // Extract Boundary Condition Line Data
@@ -11,16 +11,16 @@ import (
 
 // Main struct for focing data.
 type ForcingData struct {
-	Steady        map[string]SteadyData   `json:"Steady,omitempty"`
-	QuasiUnsteady map[string]interface{}  `json:"QuasiUnsteady,omitempty"` // to be implemented
-	Unsteady      map[string]UnsteadyData `json:"Unsteady,omitempty"`
+	Steady        map[string]SteadyData        `json:"Steady,omitempty"`
+	QuasiUnsteady map[string]QuasiUnsteadyData `json:"QuasiUnsteady,omitempty"`
+	Unsteady      map[string]UnsteadyData      `json:"Unsteady,omitempty"`
 }
 
 // Boundary Condition.
 type BoundaryCondition struct {
 	RS          string      `json:",omitempty"`            // only exists for unsteady rivers
 	BCLine      string      `json:"bc_line,omitempty"`     // only exists for unsteady storage and 2D areas
-	Description string      `json:"description,omitempty"` // only exists for Rules, not implemented yet
+	Description string      `json:"description,omitempty"` // only exists for Rules
 	Type        string      `json:"type"`
 	Data        interface{} `json:"data"`
 }
@@ -35,8 +35,7 @@ func GetForcingData(fd *ForcingData, fs filestore.FileStore, flowFilePath string
 	} else if extPrefix == ".u" {
 		err = getUnsteadyData(fd, fs, flowFilePath, mu)
 	} else if extPrefix == ".q" {
-		flowFileName := filepath.Base(flowFilePath)
-		fd.QuasiUnsteady[flowFileName] = "Not Implemented"
+		err = getQuasiUnsteadyData(fd, fs, flowFilePath, mu)
 	}
 
 	c <- err
@@ -0,0 +1,116 @@
+// Structs and functions used to export geospatial data as GeoJSON.
+
+package tools
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+
+	"github.com/dewberry/gdal"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// FeatureCollection GeoJSON feature collection
+type FeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []GeoJSONFeature `json:"features"`
+}
+
+// GeoJSONFeature GeoJSON feature, Fields of the vector feature are stored as properties
+type GeoJSONFeature struct {
+	Type       string                 `json:"type"`
+	Properties map[string]interface{} `json:"properties"`
+	Geometry   json.RawMessage        `json:"geometry"`
+}
+
+// layers of a geometry file by layer name
+func (f Features) layers() map[string][]VectorFeature {
+	return map[string][]VectorFeature{
+		"Rivers":              f.Rivers,
+		"XS":                  f.XS,
+		"Banks":               f.Banks,
+		"StorageAreas":        f.StorageAreas,
+		"TwoDAreas":           f.TwoDAreas,
+		"Mesh":                f.Mesh,
+		"HydraulicStructures": f.HydraulicStructures,
+		"Connections":         f.Connections,
+		"BCLines":             f.BCLines,
+		"BreakLines":          f.BreakLines,
+		"IneffectiveAreas":    f.IneffectiveAreas,
+		"Levees":              f.Levees,
+		"BlockedObstructions": f.BlockedObstructions,
+		"LateralStructures":   f.LateralStructures,
+		"PumpStations":        f.PumpStations,
+		"Junctions":           f.Junctions,
+	}
+}
+
+// IsLayer checks if a layer name is one of the geospatial Features layers
+func IsLayer(layer string) bool {
+	_, ok := (Features{}).layers()[layer]
+	return ok
+}
+
+// Convert a vector feature to a GeoJSON feature
+func geoJSONFeature(vf VectorFeature, layer string, geomFile string) (GeoJSONFeature, error) {
+	feature := GeoJSONFeature{Type: "Feature", Properties: make(map[string]interface{})}
+
+	geom, err := gdal.CreateFromWKB(vf.Geometry, gdal.SpatialReference{}, len(vf.Geometry))
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	defer geom.Destroy()
+	feature.Geometry = json.RawMessage(geom.ToJSON())
+
+	for key, val := range vf.Fields {
+		feature.Properties[key] = val
+	}
+	feature.Properties["feature_name"] = vf.FeatureName
+	feature.Properties["layer"] = layer
+	feature.Properties["geom_file"] = geomFile
+
+	return feature, nil
+}
+
+// FeatureCollection returns the features of the model as a GeoJSON feature collection.
+// Features can be filtered by layer e.g. 'XS' and by geometry file name or extension e.g. '.g01'
+func (gd GeoData) FeatureCollection(layer string, geomFile string) (FeatureCollection, error) {
+	fc := FeatureCollection{Type: "FeatureCollection", Features: []GeoJSONFeature{}}
+
+	if layer != "" && !IsLayer(layer) {
+		return fc, errors.Errorf("%s is not a valid layer", layer)
+	}
+
+	geomFiles := []string{}
+	for geomFileName := range gd.Features {
+		if geomFile == "" || geomFile == geomFileName || geomFile == filepath.Ext(geomFileName) {
+			geomFiles = append(geomFiles, geomFileName)
+		}
+	}
+	sort.Strings(geomFiles)
+
+	for _, geomFileName := range geomFiles {
+		layers := gd.Features[geomFileName].layers()
+
+		layerNames := []string{}
+		for layerName := range layers {
+			if layer == "" || layer == layerName {
+				layerNames = append(layerNames, layerName)
+			}
+		}
+		sort.Strings(layerNames)
+
+		for _, layerName := range layerNames {
+			for _, vf := range layers[layerName] {
+				feature, err := geoJSONFeature(vf, layerName, geomFileName)
+				if err != nil {
+					return fc, errors.Wrap(err, 0)
+				}
+				fc.Features = append(fc.Features, feature)
+			}
+		}
+	}
+
+	return fc, nil
+}
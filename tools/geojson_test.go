@@ -0,0 +1,85 @@
+package tools
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// pointWKB little endian WKB of a point
+func pointWKB(x, y float64) []uint8 {
+	wkb := []uint8{1}
+	wkb = binary.LittleEndian.AppendUint32(wkb, 1)
+	wkb = binary.LittleEndian.AppendUint64(wkb, math.Float64bits(x))
+	return binary.LittleEndian.AppendUint64(wkb, math.Float64bits(y))
+}
+
+func testGeoData() GeoData {
+	return GeoData{
+		Georeference: 4326,
+		CRS:          "EPSG:4326",
+		Features: map[string]Features{
+			"Muncie.g01": {
+				XS:        []VectorFeature{{FeatureName: "15696.24", Fields: map[string]interface{}{"RiverName": "White"}, Geometry: pointWKB(-85.4, 40.2)}},
+				Junctions: []VectorFeature{{FeatureName: "Confluence", Geometry: pointWKB(-85.3, 40.1)}},
+			},
+			"Muncie.g02": {
+				XS: []VectorFeature{{FeatureName: "15485.51", Geometry: pointWKB(-85.5, 40.3)}},
+			},
+		},
+	}
+}
+
+func TestIsLayer(t *testing.T) {
+	for layer, want := range map[string]bool{"XS": true, "Mesh": true, "Junctions": true, "xs": false, "Land Cover": false} {
+		if got := IsLayer(layer); got != want {
+			t.Errorf("IsLayer(%q) = %v, want %v", layer, got, want)
+		}
+	}
+}
+
+func TestFeatureCollectionInvalidLayer(t *testing.T) {
+	if _, err := testGeoData().FeatureCollection("Cross Sections", ""); err == nil {
+		t.Error("expected an error for an invalid layer")
+	}
+}
+
+func TestFeatureCollection(t *testing.T) {
+	gd := testGeoData()
+
+	fc, err := gd.FeatureCollection("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fc.Type != "FeatureCollection" || len(fc.Features) != 3 {
+		t.Fatalf("got %+v", fc)
+	}
+	// features are ordered by geometry file then layer
+	first := fc.Features[0]
+	if first.Properties["geom_file"] != "Muncie.g01" || first.Properties["layer"] != "Junctions" || first.Properties["feature_name"] != "Confluence" {
+		t.Errorf("got properties %v", first.Properties)
+	}
+	xs := fc.Features[1]
+	if xs.Properties["layer"] != "XS" || xs.Properties["RiverName"] != "White" {
+		t.Errorf("got properties %v", xs.Properties)
+	}
+	var geom struct {
+		Type        string     `json:"type"`
+		Coordinates [2]float64 `json:"coordinates"`
+	}
+	if err := json.Unmarshal(xs.Geometry, &geom); err != nil {
+		t.Fatal(err)
+	}
+	if geom.Type != "Point" || geom.Coordinates != [2]float64{-85.4, 40.2} {
+		t.Errorf("got geometry %s", xs.Geometry)
+	}
+
+	fc, err = gd.FeatureCollection("XS", ".g02")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fc.Features) != 1 || fc.Features[0].Properties["feature_name"] != "15485.51" {
+		t.Errorf("filtered by layer and geometry file: got %+v", fc.Features)
+	}
+}
@@ -7,23 +7,27 @@ import (
 	"io"
 	"log"
 	"path/filepath"
+	"sort"
 	"strings"
 	"sync"
 )
 
 // GeomFileContents keywords and data container for ras flow file search
 type GeomFileContents struct {
-	Path           string
-	Hash           string
-	FileExt        string                 `json:"File Extension"`
-	GeomTitle      string                 `json:"Geom Title"`
-	ProgramVersion string                 `json:"Program Version"`
-	Description    string                 `json:"Description"`
-	Structures     []hydraulicStructures  `json:"Hydraulic Structures"`
-	StorageAreas   map[string]StorageArea `json:"Storage Areas"`
-	TwoDAreas      map[string]TwoDArea    `json:"2D Areas"`
-	Connections    map[string]Connection  `json:"Connections"`
-	Notes          string
+	Path              string
+	Hash              string
+	FileExt           string                      `json:"File Extension"`
+	GeomTitle         string                      `json:"Geom Title"`
+	ProgramVersion    string                      `json:"Program Version"`
+	Description       string                      `json:"Description"`
+	Structures        []hydraulicStructures       `json:"Hydraulic Structures"`
+	LateralStructures map[string]LateralStructure `json:"Lateral Structures"`
+	StorageAreas      map[string]StorageArea      `json:"Storage Areas"`
+	TwoDAreas         map[string]TwoDArea         `json:"2D Areas"`
+	Connections       map[string]Connection       `json:"Connections"`
+	PumpStations      map[string]PumpStation      `json:"Pump Stations"`
+	Junctions         map[string]Junction         `json:"Junctions"`
+	Notes             string
 }
 
 // getGeomData Reads a geometry file. returns none to allow concurrency
@@ -32,11 +36,14 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	meta := GeomFileContents{
-		Path:         fn,
-		FileExt:      filepath.Ext(fn),
-		StorageAreas: make(map[string]StorageArea),
-		TwoDAreas:    make(map[string]TwoDArea),
-		Connections:  make(map[string]Connection),
+		Path:              fn,
+		FileExt:           filepath.Ext(fn),
+		StorageAreas:      make(map[string]StorageArea),
+		TwoDAreas:         make(map[string]TwoDArea),
+		Connections:       make(map[string]Connection),
+		LateralStructures: make(map[string]LateralStructure),
+		PumpStations:      make(map[string]PumpStation),
+		Junctions:         make(map[string]Junction),
 	}
 
 	var err error
@@ -61,6 +68,9 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 	sc := bufio.NewScanner(fs)
 
 	var description string
+	var riverReach string
+	var breakLines, refinementRegions []meshRegion
+	var manningsRegions []manningsRegion
 
 	header := true
 	idx := 0
@@ -86,7 +96,8 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 
 		// the following functions cannot take the same scanner because they can reach the eof searching for content and exhaust the main scanner
 		case strings.HasPrefix(line, "River Reach="):
-			structures, err := getHydraulicStructureData(rm, fn, idx)
+			riverReach = rightofEquals(line)
+			structures, err := getHydraulicStructureData(rm.FileStore, fn, idx)
 			if err != nil {
 				log.Println("Hydraulic Structures|", meta.FileExt, err)
 				continue
@@ -94,6 +105,14 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 			meta.Structures = append(meta.Structures, structures)
 			header = false
 
+		case strings.HasPrefix(line, "Type RM Length L Ch R = 6"):
+			lateralName, lateralData, err := getLateralStructureData(rm, fn, idx, riverReach)
+			if err != nil {
+				log.Println("Lateral Structures|", meta.FileExt, err)
+				continue
+			}
+			meta.LateralStructures[lateralName] = lateralData
+
 		case strings.HasPrefix(line, "Storage Area="):
 			areaName, areaData, err := getAreasData(rm, fn, idx)
 			if err != nil {
@@ -119,6 +138,51 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 			meta.Connections[connName] = connecData
 			header = false
 
+		case strings.HasPrefix(line, "Junct Name="):
+			junctName, junctData, err := getJunctionData(rm, fn, idx)
+			if err != nil {
+				log.Println("Junctions|", meta.FileExt, err)
+				continue
+			}
+			meta.Junctions[junctName] = junctData
+			header = false
+
+		case strings.HasPrefix(line, "Pump Station="):
+			pumpName, pumpData, err := getPumpStationData(rm, fn, idx)
+			if err != nil {
+				log.Println("Pump Stations|", meta.FileExt, err)
+				continue
+			}
+			meta.PumpStations[pumpName] = pumpData
+			header = false
+
+		case strings.HasPrefix(line, "BreakLine Name="):
+			breakLine, err := getMeshRegionData(rm, fn, idx, "BreakLine")
+			if err != nil {
+				log.Println("Break Lines|", meta.FileExt, err)
+				continue
+			}
+			breakLines = append(breakLines, breakLine)
+			header = false
+
+		case strings.HasPrefix(line, "Refinement Region Name="):
+			refinementRegion, err := getMeshRegionData(rm, fn, idx, "Refinement Region")
+			if err != nil {
+				log.Println("Refinement Regions|", meta.FileExt, err)
+				continue
+			}
+			refinementRegions = append(refinementRegions, refinementRegion)
+			header = false
+
+		case strings.HasPrefix(line, "LCMann Region Name="):
+			manningsRegion, err := getManningsRegionData(rm, fn, idx)
+			if err != nil {
+				log.Println("Mannings n Regions|", meta.FileExt, err)
+				continue
+			}
+			manningsRegions = append(manningsRegions, manningsRegion)
+			header = false
+
 		case strings.HasPrefix(line, "BC Line Name="):
 			bcArea, bc, err := getBCLineData(rm, fn, idx)
 			if err != nil {
@@ -138,6 +202,45 @@ func getGeomData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 
 		}
 	}
+
+	// break lines and regions are not tied to a 2D area in the geometry file,
+	// they are assigned to the area containing most of their points
+	areaNames := make([]string, 0, len(meta.TwoDAreas))
+	for name := range meta.TwoDAreas {
+		areaNames = append(areaNames, name)
+	}
+	sort.Strings(areaNames)
+	containingArea := func(xyPairs [][2]float64) (string, bool) {
+		areaName, maxFraction := "", 0.5
+		for _, name := range areaNames {
+			if fraction := fractionInPolygon(xyPairs, meta.TwoDAreas[name].perimeter); fraction > maxFraction {
+				areaName, maxFraction = name, fraction
+			}
+		}
+		return areaName, areaName != ""
+	}
+	for _, bl := range breakLines {
+		if areaName, ok := containingArea(bl.xyPairs); ok {
+			area := meta.TwoDAreas[areaName]
+			area.BreakLines = append(area.BreakLines, bl)
+			meta.TwoDAreas[areaName] = area
+		}
+	}
+	for _, rr := range refinementRegions {
+		if areaName, ok := containingArea(rr.xyPairs); ok {
+			area := meta.TwoDAreas[areaName]
+			area.RefinementRegions = append(area.RefinementRegions, rr)
+			meta.TwoDAreas[areaName] = area
+		}
+	}
+	for _, mr := range manningsRegions {
+		if areaName, ok := containingArea(mr.xyPairs); ok {
+			area := meta.TwoDAreas[areaName]
+			area.ManningsRegions = append(area.ManningsRegions, mr)
+			meta.TwoDAreas[areaName] = area
+		}
+	}
+
 	msg = ""
 	meta.Hash = fmt.Sprintf("%x", hasher.Sum(nil))
 
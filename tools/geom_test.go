@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func readTestGeomData(t *testing.T, fn string) GeomFileContents {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm := RasModel{FileStore: fs}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	getGeomData(&rm, fn, &wg)
+
+	if len(rm.Metadata.GeomFiles) != 1 || rm.Metadata.GeomFiles[0].Notes != "" {
+		t.Fatalf("got geometry files %+v", rm.Metadata.GeomFiles)
+	}
+	return rm.Metadata.GeomFiles[0]
+}
+
+func TestGetPumpStationData(t *testing.T) {
+	g := readTestGeomData(t, "testdata/Pumps.g01")
+
+	want := map[string]PumpStation{
+		"Pump 1": {
+			Description: "Drainage pumps",
+			FromType:    "Reach",
+			From:        "White, Muncie, 5000",
+			ToType:      "Area",
+			To:          "Upper SA",
+			NumGroups:   2,
+			Groups: []pumpGroups{
+				{
+					Name:            "Group #1",
+					NumPumps:        2,
+					PumpCurve:       [][2]float64{{0, 100}, {5, 80}, {10, 50}},
+					OnOffElevations: [][2]float64{{945, 943}, {946, 944}},
+				},
+				{Name: "Group #2"},
+			},
+		},
+		"Pump 2": {
+			FromType: "Area",
+			From:     "Upper SA",
+			ToType:   "Area",
+			To:       "Perimeter 1",
+		},
+	}
+	if !reflect.DeepEqual(g.PumpStations, want) {
+		t.Errorf("got pump stations %+v\nwant %+v", g.PumpStations, want)
+	}
+}
+
+func TestGetJunctionData(t *testing.T) {
+	g := readTestGeomData(t, "testdata/Junctions.g01")
+
+	// 'Junc L&A' belongs to the reach listed right before it, junctions use energy unless momentum is selected
+	want := map[string]Junction{
+		"Sutter": {
+			Description: "Confluence of the tributary",
+			UpReaches: []junctionReach{
+				{River: "Butte Cr.", Reach: "Upper Reach"},
+				{River: "Tributary", Reach: "Trib", Length: 20, Angle: 30},
+			},
+			DnReaches:       []junctionReach{{River: "Butte Cr.", Reach: "Lower Reach", Length: 15}},
+			ComputationMode: "Energy",
+		},
+		"Split": {
+			UpReaches: []junctionReach{{River: "Butte Cr.", Reach: "Lower Reach"}},
+			DnReaches: []junctionReach{
+				{River: "Butte Cr.", Reach: "Main Channel"},
+				{River: "Spring Cr.", Reach: "Side Channel", Length: 50, Angle: 45},
+			},
+			ComputationMode: "Momentum",
+		},
+	}
+	if !reflect.DeepEqual(g.Junctions, want) {
+		t.Errorf("got junctions %+v\nwant %+v", g.Junctions, want)
+	}
+}
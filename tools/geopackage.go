@@ -0,0 +1,206 @@
+// Structs and functions used to export geospatial data as a GeoPackage.
+
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dewberry/gdal"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Column types used to store vector feature Fields
+const (
+	realField    = "real"
+	integerField = "integer"
+	stringField  = "string" // lists and maps are stored as JSON strings
+)
+
+// Get the column type of a field value
+func fieldKind(val interface{}) string {
+	switch val.(type) {
+	case float64, float32:
+		return realField
+	case int, int64, bool:
+		return integerField
+	}
+	return stringField
+}
+
+// Get the column types of the fields of a layer.
+// Fields holding different types of values in different features are stored as strings
+func layerFieldKinds(features []VectorFeature) map[string]string {
+	kinds := make(map[string]string)
+	for _, vf := range features {
+		for key, val := range vf.Fields {
+			if val == nil {
+				continue
+			}
+			kind := fieldKind(val)
+			if existing, ok := kinds[key]; ok && existing != kind {
+				kind = stringField
+			}
+			kinds[key] = kind
+		}
+	}
+	return kinds
+}
+
+// Set the value of a field on a GeoPackage feature
+func setFeatureField(feature gdal.Feature, index int, kind string, val interface{}) error {
+	switch kind {
+	case realField:
+		switch v := val.(type) {
+		case float64:
+			feature.SetFieldFloat64(index, v)
+		case float32:
+			feature.SetFieldFloat64(index, float64(v))
+		}
+
+	case integerField:
+		switch v := val.(type) {
+		case int:
+			feature.SetFieldInteger(index, v)
+		case int64:
+			feature.SetFieldInteger64(index, v)
+		case bool:
+			if v {
+				feature.SetFieldInteger(index, 1)
+			} else {
+				feature.SetFieldInteger(index, 0)
+			}
+		}
+
+	default:
+		if s, ok := val.(string); ok {
+			feature.SetFieldString(index, s)
+			return nil
+		}
+		b, err := json.Marshal(val)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		feature.SetFieldString(index, string(b))
+	}
+	return nil
+}
+
+// Write the features of a layer to a new GeoPackage table
+func writeGeoPackageLayer(ds gdal.DataSource, srs gdal.SpatialReference, tableName string, features []VectorFeature) error {
+	// layers such as the mesh hold more than one geometry type
+	layer := ds.CreateLayer(tableName, srs, gdal.GT_Unknown, []string{})
+
+	kinds := layerFieldKinds(features)
+	fieldNames := []string{"feature_name"}
+	for key := range kinds {
+		fieldNames = append(fieldNames, key)
+	}
+	sort.Strings(fieldNames[1:])
+	kinds["feature_name"] = stringField
+
+	gdalFieldTypes := map[string]gdal.FieldType{realField: gdal.FT_Real, integerField: gdal.FT_Integer, stringField: gdal.FT_String}
+	for _, name := range fieldNames {
+		fd := gdal.CreateFieldDefinition(name, gdalFieldTypes[kinds[name]])
+		err := layer.CreateField(fd, true)
+		fd.Destroy()
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+	}
+
+	if err := layer.StartTransaction(); err != nil {
+		return errors.Wrap(err, 0)
+	}
+	for _, vf := range features {
+		feature := layer.Definition().Create()
+
+		geom, err := gdal.CreateFromWKB(vf.Geometry, gdal.SpatialReference{}, len(vf.Geometry))
+		if err != nil {
+			feature.Destroy()
+			layer.RollbackTransaction()
+			return errors.Wrap(err, 0)
+		}
+		if err := feature.SetGeometryDirectly(geom); err != nil {
+			feature.Destroy()
+			layer.RollbackTransaction()
+			return errors.Wrap(err, 0)
+		}
+
+		fieldValues := map[string]interface{}{"feature_name": vf.FeatureName}
+		for key, val := range vf.Fields {
+			fieldValues[key] = val
+		}
+		for _, name := range fieldNames {
+			val, ok := fieldValues[name]
+			if !ok || val == nil {
+				continue
+			}
+			if err := setFeatureField(feature, feature.FieldIndex(name), kinds[name], val); err != nil {
+				feature.Destroy()
+				layer.RollbackTransaction()
+				return errors.Wrap(err, 0)
+			}
+		}
+
+		err = layer.Create(feature)
+		feature.Destroy()
+		if err != nil {
+			layer.RollbackTransaction()
+			return errors.Wrap(err, 0)
+		}
+	}
+	if err := layer.CommitTransaction(); err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+// WriteGeoPackage writes every layer of every geometry file to a GeoPackage,
+// one table per layer per geometry file e.g. 'Muncie_g01_XS'
+func (gd GeoData) WriteGeoPackage(fn string) error {
+	ds, ok := gdal.OGRDriverByName("GPKG").Create(fn, []string{})
+	if !ok {
+		return errors.Errorf("could not create GeoPackage %s", fn)
+	}
+	defer ds.Destroy()
+
+	srs := gdal.CreateSpatialReference("")
+	defer srs.Destroy()
+	if gd.CRS != "" {
+		if err := srs.SetFromUserInput(gd.CRS); err != nil {
+			return errors.Wrap(err, 0)
+		}
+	} else if err := srs.FromEPSG(gd.Georeference); err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	geomFiles := []string{}
+	for geomFileName := range gd.Features {
+		geomFiles = append(geomFiles, geomFileName)
+	}
+	sort.Strings(geomFiles)
+
+	for _, geomFileName := range geomFiles {
+		layers := gd.Features[geomFileName].layers()
+
+		layerNames := []string{}
+		for layerName := range layers {
+			layerNames = append(layerNames, layerName)
+		}
+		sort.Strings(layerNames)
+
+		for _, layerName := range layerNames {
+			if len(layers[layerName]) == 0 {
+				continue
+			}
+			tableName := fmt.Sprintf("%s_%s", strings.ReplaceAll(geomFileName, ".", "_"), layerName)
+			if err := writeGeoPackageLayer(ds, srs, tableName, layers[layerName]); err != nil {
+				return errors.Wrap(err, 0)
+			}
+		}
+	}
+	return nil
+}
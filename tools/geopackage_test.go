@@ -0,0 +1,63 @@
+package tools
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/dewberry/gdal"
+)
+
+func TestLayerFieldKinds(t *testing.T) {
+	features := []VectorFeature{
+		{Fields: map[string]interface{}{"Elevation": 950.5, "CellID": 1, "Levee": true, "Mixed": 1.5, "Empty": nil}},
+		{Fields: map[string]interface{}{"Elevation": 951.0, "Mixed": "left", "Stations": []float64{0, 10}}},
+	}
+
+	want := map[string]string{
+		"Elevation": realField,
+		"CellID":    integerField,
+		"Levee":     integerField,
+		"Mixed":     stringField,
+		"Stations":  stringField,
+	}
+	if got := layerFieldKinds(features); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestWriteGeoPackage(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "Muncie.gpkg")
+	if err := testGeoData().WriteGeoPackage(fn); err != nil {
+		t.Fatal(err)
+	}
+
+	ds := gdal.OpenDataSource(fn, 0)
+	defer ds.Destroy()
+
+	// one table per layer per geometry file, empty layers are skipped
+	if ds.LayerCount() != 3 {
+		t.Errorf("got %d tables, want 3", ds.LayerCount())
+	}
+	for table, want := range map[string]int{"Muncie_g01_XS": 1, "Muncie_g01_Junctions": 1, "Muncie_g02_XS": 1} {
+		if count, _ := ds.LayerByName(table).FeatureCount(true); count != want {
+			t.Errorf("table %s: got %d features, want %d", table, count, want)
+		}
+	}
+
+	layer := ds.LayerByName("Muncie_g01_XS")
+	feature := layer.NextFeature()
+	if feature == nil {
+		t.Fatal("no feature in table Muncie_g01_XS")
+	}
+	defer feature.Destroy()
+	if got := feature.FieldAsString(feature.FieldIndex("feature_name")); got != "15696.24" {
+		t.Errorf("got feature_name %s", got)
+	}
+	if got := feature.FieldAsString(feature.FieldIndex("RiverName")); got != "White" {
+		t.Errorf("got RiverName %s", got)
+	}
+	if geom := feature.Geometry(); geom.X(0) != -85.4 || geom.Y(0) != 40.2 {
+		t.Errorf("got point %v %v", geom.X(0), geom.Y(0))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"math"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/USACE/filestore"
@@ -18,7 +19,37 @@ import (
 // GeoData ...
 type GeoData struct {
 	Features     map[string]Features
-	Georeference int
+	Georeference int    // EPSG code of the coordinates, 0 if their coordinate reference system has no EPSG code
+	CRS          string // coordinate reference system of the coordinates e.g. EPSG:4326, or the model projection when not transformed
+}
+
+// NewGeoData returns an empty GeoData in the destination coordinate reference system,
+// or in the source coordinate reference system when the destination is none
+func NewGeoData(sourceCRS string, destinationCRS string) GeoData {
+	crs := destinationCRS
+	if crs == "none" {
+		crs = sourceCRS
+	}
+	return GeoData{Features: make(map[string]Features), Georeference: epsgCode(crs), CRS: crs}
+}
+
+// Get the EPSG code of a coordinate reference system, 0 if it cannot be identified
+func epsgCode(crs string) int {
+	spRef := gdal.CreateSpatialReference("")
+	defer spRef.Destroy()
+	if err := spRef.SetFromUserInput(crs); err != nil {
+		return 0
+	}
+	if spRef.AuthorityName("") != "EPSG" {
+		if err := spRef.AutoIdentifyEPSG(); err != nil || spRef.AuthorityName("") != "EPSG" {
+			return 0
+		}
+	}
+	code, err := strconv.Atoi(spRef.AuthorityCode(""))
+	if err != nil {
+		return 0
+	}
+	return code
 }
 
 // Features ...
@@ -28,11 +59,17 @@ type Features struct {
 	Banks               []VectorFeature
 	StorageAreas        []VectorFeature
 	TwoDAreas           []VectorFeature
-	Mesh                []VectorFeature
+	Mesh                []VectorFeature // see getHDFMeshArea and getMeshArea for the features of each MeshSource
 	HydraulicStructures []VectorFeature
 	Connections         []VectorFeature
 	BCLines             []VectorFeature
 	BreakLines          []VectorFeature
+	IneffectiveAreas    []VectorFeature
+	Levees              []VectorFeature
+	BlockedObstructions []VectorFeature
+	LateralStructures   []VectorFeature
+	PumpStations        []VectorFeature
+	Junctions           []VectorFeature
 }
 
 // VectorFeature ...
@@ -42,6 +79,53 @@ type VectorFeature struct {
 	Geometry    []uint8                `json:"geometry"`
 }
 
+// Manning's n value of a cross section starting at a station
+type XSManningsN struct {
+	Station float64 `json:"station"`
+	N       float64 `json:"n"`
+}
+
+// Ineffective flow area or blocked obstruction of a cross section
+type XSStationBlock struct {
+	StartStation float64 `json:"start_station"`
+	EndStation   float64 `json:"end_station"`
+	Elevation    float64 `json:"elevation"`
+	Permanent    bool    `json:"permanent,omitempty"` // only exists for ineffective flow areas
+}
+
+// Levee of a cross section
+type XSLevee struct {
+	Station   float64 `json:"station"`
+	Elevation float64 `json:"elevation"`
+}
+
+// Cross section hydraulic attributes, these lines come after the cut line and station elevation data
+var xsAttributesPrefix = [...]string{"#Mann=", "#XS Ineff=", "Permanent Ineff=", "#Block Obstruct=", "Levee=", "Exp/Cntr="}
+
+// Cross section being read, its hydraulic attribute layers are created once all of its lines are read
+type xsCutLine struct {
+	feature         VectorFeature
+	xyPairs         [][2]float64
+	startingStation float64
+}
+
+// Map of HEC-RAS node type to 1D hydraulic structure type
+var structureTypes map[string]string = map[string]string{
+	"2": "Culvert",
+	"3": "Bridge",
+	"5": "Inline Weir"}
+
+// Hydraulic structure, the cross sections bounding it and the lines describing it
+type structureLocation struct {
+	riverReachName string
+	structureType  string
+	station        float64
+	lineData       []string // data of the structure's 'Type RM Length L Ch R' line
+	lines          []string
+	upstreamXS     *xsCutLine
+	downstreamXS   *xsCutLine
+}
+
 type xyzPoint struct {
 	x float64
 	y float64
@@ -128,19 +212,32 @@ func attributeZ(xyPairs [][2]float64, mzPairs [][2]float64) []xyzPoint {
 	return points
 }
 
-func getTransform(sourceCRS string, destinationCRS int) (gdal.CoordinateTransform, error) {
+// destinationCRS can be an EPSG code e.g. 'EPSG:2277', a WKT or PROJ string,
+// or 'none' to keep the native coordinates of the model
+func getTransform(sourceCRS string, destinationCRS string) (gdal.CoordinateTransform, error) {
 	transform := gdal.CoordinateTransform{}
 	sourceSpRef := gdal.CreateSpatialReference(sourceCRS)
 
 	destinationSpRef := gdal.CreateSpatialReference("")
-	if err := destinationSpRef.FromEPSG(destinationCRS); err != nil {
+	if destinationCRS == "none" {
+		destinationSpRef = gdal.CreateSpatialReference(sourceCRS)
+	} else if err := destinationSpRef.SetFromUserInput(destinationCRS); err != nil {
 		return transform, errors.Wrap(err, 0)
 	}
 	transform = gdal.CreateCoordinateTransform(sourceSpRef, destinationSpRef)
 	return transform, nil
 }
 
+// Transformed geometries are in the axis order of the destination CRS e.g. lat/long for EPSG:4326.
+// Checks if the x and y values need to be flipped to get long/lat
+func isLatLong(geom gdal.Geometry) bool {
+	return geom.SpatialReference().EPSGTreatsAsLatLong()
+}
+
 func flipXYLineString(xyLineString gdal.Geometry) gdal.Geometry {
+	if !isLatLong(xyLineString) {
+		return xyLineString
+	}
 	yxLineString := gdal.Create(gdal.GT_LineString)
 	nPoints := xyLineString.PointCount()
 	for i := 0; i < nPoints; i++ {
@@ -152,6 +249,9 @@ func flipXYLineString(xyLineString gdal.Geometry) gdal.Geometry {
 }
 
 func flipXYLineString25D(xyzLineString gdal.Geometry) gdal.Geometry {
+	if !isLatLong(xyzLineString) {
+		return xyzLineString
+	}
 	yxzLineString := gdal.Create(gdal.GT_LineString25D)
 	nPoints := xyzLineString.PointCount()
 	for i := 0; i < nPoints; i++ {
@@ -163,6 +263,9 @@ func flipXYLineString25D(xyzLineString gdal.Geometry) gdal.Geometry {
 }
 
 func flipXYLinearRing(xyLinearRing gdal.Geometry) gdal.Geometry {
+	if !isLatLong(xyLinearRing) {
+		return xyLinearRing
+	}
 	yxLinearRing := gdal.Create(gdal.GT_LinearRing)
 	nPoints := xyLinearRing.PointCount()
 	for i := 0; i < nPoints; i++ {
@@ -174,6 +277,9 @@ func flipXYLinearRing(xyLinearRing gdal.Geometry) gdal.Geometry {
 }
 
 func flipXYPoint(xyPoint gdal.Geometry) gdal.Geometry {
+	if !isLatLong(xyPoint) {
+		return xyPoint
+	}
 	yxPoint := gdal.Create(gdal.GT_Point)
 	nPoints := xyPoint.PointCount()
 	for i := 0; i < nPoints; i++ {
@@ -193,13 +299,13 @@ func toNumeric(s string) (string, error) {
 	return num, nil
 }
 
-func getRiverCenterline(sc *bufio.Scanner, transform gdal.CoordinateTransform) (VectorFeature, error) {
+func getRiverCenterline(sc *bufio.Scanner, transform gdal.CoordinateTransform) (VectorFeature, [][2]float64, error) {
 	riverReach := strings.Split(rightofEquals(sc.Text()), ",")
 	feature := VectorFeature{FeatureName: fmt.Sprintf("%s, %s", strings.TrimSpace(riverReach[0]), strings.TrimSpace(riverReach[1]))}
 
 	xyPairs, err := getDataPairsfromTextBlock("Reach XY=", sc, 64, 16)
 	if err != nil {
-		return feature, errors.Wrap(err, 0)
+		return feature, xyPairs, errors.Wrap(err, 0)
 	}
 
 	xyLineString := gdal.Create(gdal.GT_LineString)
@@ -215,34 +321,142 @@ func getRiverCenterline(sc *bufio.Scanner, transform gdal.CoordinateTransform) (
 
 	wkb, err := multiLineString.ToWKB()
 	if err != nil {
-		return feature, errors.Wrap(err, 0)
+		return feature, xyPairs, errors.Wrap(err, 0)
 	}
 	feature.Geometry = wkb
-	return feature, nil
+	return feature, xyPairs, nil
 }
 
-func getXSBanks(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string) (VectorFeature, []VectorFeature, error) {
+func getXSBanks(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string) (xsCutLine, []VectorFeature, error) {
 	bankLayer := []VectorFeature{}
 
 	xsFeature, xyPairs, startingStation, err := getXS(sc, transform, riverReachName)
+	xs := xsCutLine{feature: xsFeature, xyPairs: xyPairs, startingStation: startingStation}
 	if err != nil {
-		return xsFeature, bankLayer, errors.Wrap(err, 0)
+		return xs, bankLayer, errors.Wrap(err, 0)
 	}
 
 	if xsFeature.Fields["CutLineProfileMatch"].(bool) {
 		for sc.Scan() {
 			line := sc.Text()
+			if isXSAttribute(line) {
+				if err := getXSAttributes(sc, xsFeature); err != nil {
+					return xs, bankLayer, errors.Wrap(err, 0)
+				}
+			}
 			if strings.HasPrefix(line, "Bank Sta=") {
 				bankLayer, err = getBanks(line, transform, xsFeature, xyPairs, startingStation)
 				if err != nil {
-					return xsFeature, bankLayer, errors.Wrap(err, 0)
+					return xs, bankLayer, errors.Wrap(err, 0)
 				}
 				break
 			}
 		}
 	}
 
-	return xsFeature, bankLayer, nil
+	return xs, bankLayer, nil
+}
+
+// xsSegment returns the part of a cross section cut line between two stations
+func xsSegment(xyPairs [][2]float64, startStation float64, endStation float64) [][2]float64 {
+	segment := [][2]float64{interpXY(xyPairs, startStation)}
+	lineLength := 0.0
+	for i := 1; i < len(xyPairs); i++ {
+		lineLength += distance(xyPairs[i-1], xyPairs[i])
+		if lineLength > startStation && lineLength < endStation {
+			segment = append(segment, xyPairs[i])
+		}
+	}
+	return append(segment, interpXY(xyPairs, endStation))
+}
+
+// Get features of the ineffective flow areas, levees and blocked obstructions of a cross section.
+// Stations can only be placed on the cut line if it matches the station elevation profile.
+func getXSAttributeLayers(xs xsCutLine, transform gdal.CoordinateTransform) (ineffLayer []VectorFeature, leveeLayer []VectorFeature, blockLayer []VectorFeature, err error) {
+	if !xs.feature.Fields["CutLineProfileMatch"].(bool) {
+		return
+	}
+
+	lineLength := 0.0
+	for i := 1; i < len(xs.xyPairs); i++ {
+		lineLength += distance(xs.xyPairs[i-1], xs.xyPairs[i])
+	}
+
+	blockFeatures := func(blocks []XSStationBlock, isIneff bool) ([]VectorFeature, error) {
+		layer := []VectorFeature{}
+		for _, block := range blocks {
+			start := math.Max(block.StartStation-xs.startingStation, 0)
+			end := math.Min(block.EndStation-xs.startingStation, lineLength)
+			if end <= start {
+				continue
+			}
+
+			xyLineString := gdal.Create(gdal.GT_LineString)
+			for _, pair := range xsSegment(xs.xyPairs, start, end) {
+				xyLineString.AddPoint2D(pair[0], pair[1])
+			}
+			xyLineString.Transform(transform)
+			// The x and y values need to be flipped
+			yxLineString := flipXYLineString(xyLineString)
+			wkb, err := yxLineString.ForceToMultiLineString().ToWKB()
+			if err != nil {
+				return layer, errors.Wrap(err, 0)
+			}
+
+			feature := VectorFeature{FeatureName: xs.feature.FeatureName, Fields: map[string]interface{}{}, Geometry: wkb}
+			feature.Fields["RiverReachName"] = xs.feature.Fields["RiverReachName"]
+			feature.Fields["xsName"] = xs.feature.FeatureName
+			feature.Fields["StartStation"] = block.StartStation
+			feature.Fields["EndStation"] = block.EndStation
+			feature.Fields["Elevation"] = block.Elevation
+			if isIneff {
+				feature.Fields["Permanent"] = block.Permanent
+			}
+			layer = append(layer, feature)
+		}
+		return layer, nil
+	}
+
+	if blocks, ok := xs.feature.Fields["IneffectiveAreas"].([]XSStationBlock); ok {
+		ineffLayer, err = blockFeatures(blocks, true)
+		if err != nil {
+			return
+		}
+	}
+
+	if blocks, ok := xs.feature.Fields["BlockedObstructions"].([]XSStationBlock); ok {
+		blockLayer, err = blockFeatures(blocks, false)
+		if err != nil {
+			return
+		}
+	}
+
+	if levees, ok := xs.feature.Fields["Levees"].(map[string]XSLevee); ok {
+		for side, levee := range levees {
+			if levee.Station < xs.startingStation || levee.Station-xs.startingStation > lineLength {
+				continue
+			}
+			leveeXY := interpXY(xs.xyPairs, levee.Station-xs.startingStation)
+			xyPoint := gdal.Create(gdal.GT_Point)
+			xyPoint.AddPoint2D(leveeXY[0], leveeXY[1])
+			xyPoint.Transform(transform)
+			// The x and y values need to be flipped
+			yxPoint := flipXYPoint(xyPoint)
+			wkb, wkbErr := yxPoint.ForceToMultiPoint().ToWKB()
+			if wkbErr != nil {
+				err = errors.Wrap(wkbErr, 0)
+				return
+			}
+
+			feature := VectorFeature{FeatureName: side, Fields: map[string]interface{}{}, Geometry: wkb}
+			feature.Fields["RiverReachName"] = xs.feature.Fields["RiverReachName"]
+			feature.Fields["xsName"] = xs.feature.FeatureName
+			feature.Fields["Station"] = levee.Station
+			feature.Fields["Elevation"] = levee.Elevation
+			leveeLayer = append(leveeLayer, feature)
+		}
+	}
+	return
 }
 
 func getXS(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string) (VectorFeature, [][2]float64, float64, error) {
@@ -259,6 +473,17 @@ func getXS(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName
 	}
 	feature.FeatureName = xsName
 
+	// downstream reach lengths are blank for the last cross section of a reach
+	for i, field := range []string{"LOBLength", "ChannelLength", "ROBLength"} {
+		if len(compData) > i+2 && strings.TrimSpace(compData[i+2]) != "" {
+			length, err := parseFloat(strings.TrimSpace(compData[i+2]), 64)
+			if err != nil {
+				return feature, xyPairs, 0.0, errors.Wrap(err, 0)
+			}
+			feature.Fields[field] = length
+		}
+	}
+
 	xyPairs, err = getDataPairsfromTextBlock("XS GIS Cut Line", sc, 64, 16)
 	if err != nil {
 		return feature, xyPairs, 0.0, errors.Wrap(err, 0)
@@ -305,6 +530,131 @@ func getXS(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName
 	return feature, xyPairs, mzPairs[0][0], nil
 }
 
+func isXSAttribute(line string) bool {
+	for _, prefix := range xsAttributesPrefix {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get station blocks (start station, end station, elevation) e.g. ineffective flow areas and blocked obstructions
+func getXSStationBlocks(sc *bufio.Scanner) ([]XSStationBlock, error) {
+	blocks := []XSStationBlock{}
+
+	nBlocks, err := strconv.Atoi(strings.TrimSpace(strings.Split(rightofEquals(sc.Text()), ",")[0]))
+	if err != nil {
+		return blocks, errors.Wrap(err, 0)
+	}
+	// cross sections without blocks have no values to read e.g. '#XS Ineff= 0 ,-1'
+	if nBlocks == 0 {
+		return blocks, nil
+	}
+
+	values, err := seriesFromTextBlock(sc, nBlocks*3, 80, 8)
+	if err != nil {
+		return blocks, errors.Wrap(err, 0)
+	}
+	for i := 0; i < nBlocks; i++ {
+		blocks = append(blocks, XSStationBlock{StartStation: values[i*3], EndStation: values[i*3+1], Elevation: values[i*3+2]})
+	}
+	return blocks, nil
+}
+
+// Add the hydraulic attribute found at the current line of the scanner to the cross section's fields
+func getXSAttributes(sc *bufio.Scanner, xsFeature VectorFeature) error {
+	line := sc.Text()
+
+	switch {
+	case strings.HasPrefix(line, "#Mann="):
+		// e.g. '#Mann= 3 , 0 , 0' followed by station, n value, 0 triplets
+		nSegments, err := strconv.Atoi(strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0]))
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		if nSegments == 0 {
+			return nil
+		}
+		values, err := seriesFromTextBlock(sc, nSegments*3, 72, 8)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		mannings := []XSManningsN{}
+		for i := 0; i < nSegments; i++ {
+			mannings = append(mannings, XSManningsN{Station: values[i*3], N: values[i*3+1]})
+		}
+		xsFeature.Fields["ManningsN"] = mannings
+
+	case strings.HasPrefix(line, "#XS Ineff="):
+		blocks, err := getXSStationBlocks(sc)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		xsFeature.Fields["IneffectiveAreas"] = blocks
+
+	case strings.HasPrefix(line, "Permanent Ineff="):
+		// followed by a T or F flag for each ineffective flow area
+		blocks, ok := xsFeature.Fields["IneffectiveAreas"].([]XSStationBlock)
+		if !ok || len(blocks) == 0 {
+			return nil
+		}
+		flags, err := parseSeriesTextBlock(sc, len(blocks), 80, 8)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		for i, flag := range flags {
+			blocks[i].Permanent = flag == "T"
+		}
+
+	case strings.HasPrefix(line, "#Block Obstruct="):
+		blocks, err := getXSStationBlocks(sc)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		xsFeature.Fields["BlockedObstructions"] = blocks
+
+	case strings.HasPrefix(line, "Levee="):
+		// e.g. 'Levee=-1,190,575.5,-1,375,575.5' (left flag, station, elevation, right flag, station, elevation)
+		values := strings.Split(rightofEquals(line), ",")
+		levees := map[string]XSLevee{}
+		for i, side := range []string{"Left", "Right"} {
+			if len(values) < i*3+3 || strings.TrimSpace(values[i*3+1]) == "" {
+				continue
+			}
+			station, err := parseFloat(strings.TrimSpace(values[i*3+1]), 64)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			elevation, err := parseFloat(strings.TrimSpace(values[i*3+2]), 64)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			levees[side] = XSLevee{Station: station, Elevation: elevation}
+		}
+		if len(levees) > 0 {
+			xsFeature.Fields["Levees"] = levees
+		}
+
+	case strings.HasPrefix(line, "Exp/Cntr="):
+		values := strings.Split(rightofEquals(line), ",")
+		if len(values) != 2 {
+			return nil
+		}
+		expansion, err := parseFloat(strings.TrimSpace(values[0]), 64)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		contraction, err := parseFloat(strings.TrimSpace(values[1]), 64)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		xsFeature.Fields["ExpansionCoefficient"] = expansion
+		xsFeature.Fields["ContractionCoefficient"] = contraction
+	}
+	return nil
+}
+
 func getBanks(line string, transform gdal.CoordinateTransform, xsFeature VectorFeature, xyPairs [][2]float64, startingStation float64) ([]VectorFeature, error) {
 	layer := []VectorFeature{}
 
@@ -334,6 +684,150 @@ func getBanks(line string, transform gdal.CoordinateTransform, xsFeature VectorF
 	return layer, nil
 }
 
+// Get the fraction along segment p0 p1 at which it intersects segment q0 q1
+func segmentIntersection(p0, p1, q0, q1 [2]float64) (float64, bool) {
+	r := [2]float64{p1[0] - p0[0], p1[1] - p0[1]}
+	s := [2]float64{q1[0] - q0[0], q1[1] - q0[1]}
+	denom := r[0]*s[1] - r[1]*s[0]
+	if denom == 0 {
+		return 0, false
+	}
+	qp := [2]float64{q0[0] - p0[0], q0[1] - p0[1]}
+	t := (qp[0]*s[1] - qp[1]*s[0]) / denom
+	u := (qp[0]*r[1] - qp[1]*r[0]) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return 0, false
+	}
+	return t, true
+}
+
+// Get the distance along a line to its first intersection with another line
+func intersectionDistance(xyPairs [][2]float64, other [][2]float64) (float64, bool) {
+	d := 0.0
+	for i := 0; i < len(xyPairs)-1; i++ {
+		p0, p1 := xyPairs[i], xyPairs[i+1]
+		for j := 0; j < len(other)-1; j++ {
+			if t, ok := segmentIntersection(p0, p1, other[j], other[j+1]); ok {
+				return d + t*distance(p0, p1), true
+			}
+		}
+		d += distance(p0, p1)
+	}
+	return 0, false
+}
+
+// Get the distance along a reach centerline and the river station of a cross section crossing it
+func xsCenterlineDistance(xs *xsCutLine, centerline [][2]float64) (float64, float64, bool) {
+	if xs == nil {
+		return 0, 0, false
+	}
+	station, err := parseFloat(xs.feature.FeatureName, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	d, ok := intersectionDistance(centerline, xs.xyPairs)
+	return d, station, ok
+}
+
+// Get the distance along a reach centerline, drawn from upstream to downstream, of a hydraulic structure.
+// The structure is placed between the centerline crossings of its bounding cross sections in proportion to its river station.
+// If only one or neither cross section crosses the centerline, river stations are taken as lengths along the centerline
+// measured from its downstream end
+func structureCenterlineDistance(loc structureLocation, centerline [][2]float64) float64 {
+	length := 0.0
+	for i := 0; i < len(centerline)-1; i++ {
+		length += distance(centerline[i], centerline[i+1])
+	}
+
+	upDist, upStation, upOK := xsCenterlineDistance(loc.upstreamXS, centerline)
+	dnDist, dnStation, dnOK := xsCenterlineDistance(loc.downstreamXS, centerline)
+
+	var d float64
+	switch {
+	case upOK && dnOK && upStation != dnStation:
+		d = upDist + (upStation-loc.station)/(upStation-dnStation)*(dnDist-upDist)
+	case upOK:
+		d = upDist + (upStation - loc.station)
+	case dnOK:
+		d = dnDist - (loc.station - dnStation)
+	default:
+		d = length - loc.station
+	}
+	return math.Min(math.Max(d, 0), length)
+}
+
+// Get the fields of a hydraulic structure feature from the structure's lines
+func getStructureFields(loc structureLocation) (map[string]interface{}, error) {
+	fields := map[string]interface{}{"RiverReachName": loc.riverReachName, "Type": loc.structureType}
+
+	// the structure's lines were collected while reading the geometry file
+	sc := bufio.NewScanner(strings.NewReader(strings.Join(loc.lines, "\n")))
+	switch loc.structureType {
+	case "Culvert":
+		culvert, _, err := getCulvertData(sc, 0, loc.lineData)
+		if err != nil {
+			return fields, errors.Wrap(err, 0)
+		}
+		fields["Name"] = culvert.Name
+		fields["DeckWidth"] = culvert.DeckWidth
+		fields["UpHighChord"] = culvert.UpHighChord
+		fields["UpLowChord"] = culvert.UpLowChord
+		fields["DownHighChord"] = culvert.DownHighChord
+		fields["DownLowChord"] = culvert.DownLowChord
+		fields["NumConduits"] = culvert.NumConduits
+	case "Bridge":
+		bridge, _, err := getBridgeData(sc, 0, loc.lineData)
+		if err != nil {
+			return fields, errors.Wrap(err, 0)
+		}
+		fields["Name"] = bridge.Name
+		fields["DeckWidth"] = bridge.DeckWidth
+		fields["UpHighChord"] = bridge.UpHighChord
+		fields["UpLowChord"] = bridge.UpLowChord
+		fields["DownHighChord"] = bridge.DownHighChord
+		fields["DownLowChord"] = bridge.DownLowChord
+		fields["NumPiers"] = bridge.NumPiers
+	case "Inline Weir":
+		weir, err := scanWeirData(sc, loc.lineData)
+		if err != nil {
+			return fields, errors.Wrap(err, 0)
+		}
+		fields["Name"] = weir.Name
+		fields["WeirWidth"] = weir.WeirWidth
+		fields["WeirElev"] = weir.WeirElev
+		fields["NumGates"] = weir.NumGates
+		fields["NumConduits"] = weir.NumConduits
+	}
+	return fields, nil
+}
+
+// Create a hydraulic structure feature located on its reach centerline at the structure's river station
+func getStructureFeature(loc structureLocation, centerline [][2]float64, transform gdal.CoordinateTransform) (VectorFeature, error) {
+	feature := VectorFeature{FeatureName: fmt.Sprint(loc.station)}
+
+	fields, err := getStructureFields(loc)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	feature.Fields = fields
+
+	xy := interpXY(centerline, structureCenterlineDistance(loc, centerline))
+
+	xyPoint := gdal.Create(gdal.GT_Point)
+	xyPoint.AddPoint2D(xy[0], xy[1])
+	xyPoint.Transform(transform)
+	// The x and y values need to be flipped
+	yxPoint := flipXYPoint(xyPoint)
+	multiPoint := yxPoint.ForceToMultiPoint()
+
+	wkb, err := multiPoint.ToWKB()
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	feature.Geometry = wkb
+	return feature, nil
+}
+
 func getArea(sc *bufio.Scanner, transform gdal.CoordinateTransform) (VectorFeature, string, error) {
 	feature := VectorFeature{FeatureName: strings.TrimSpace(strings.Split(rightofEquals(sc.Text()), ",")[0])}
 
@@ -371,11 +865,13 @@ func validVoronoiBound(point [2]float64, bbox voronoi.BBox) bool {
 	return point[0] > bbox.Xl && point[0] < bbox.Xr && point[1] > bbox.Yt && point[1] < bbox.Yb
 }
 
-func getMeshArea(sc *bufio.Scanner, transform gdal.CoordinateTransform, allowedDist float64) ([]VectorFeature, error) {
-	features := []VectorFeature{
-		VectorFeature{FeatureName: "mesh_points"},
-		VectorFeature{FeatureName: "mesh_voronoi"},
-		VectorFeature{FeatureName: "mesh_concave"},
+// Approximate the mesh of a 2D area from its cell centers when the geometry HDF5 file is not available.
+// Features have the MeshSource field 'voronoi': mesh_points (cell centers), mesh_voronoi (approximate faces)
+// and mesh_concave (approximate perimeter).
+func getMeshArea(sc *bufio.Scanner, area string, transform gdal.CoordinateTransform, allowedDist float64) ([]VectorFeature, error) {
+	features := []VectorFeature{}
+	for _, name := range []string{"mesh_points", "mesh_voronoi", "mesh_concave"} {
+		features = append(features, VectorFeature{FeatureName: name, Fields: map[string]interface{}{"Area": area, "MeshSource": "voronoi"}})
 	}
 
 	xyPairs, err := getDataPairsfromTextBlock("Storage Area 2D Points=", sc, 64, 16)
@@ -436,6 +932,151 @@ func getMeshArea(sc *bufio.Scanner, transform gdal.CoordinateTransform, allowedD
 	return features, nil
 }
 
+// hdfMeshPolygon creates a multipolygon from the facepoints of a mesh cell
+func hdfMeshPolygon(facePointIdxs []float64, facePoints [][]float64, transform gdal.CoordinateTransform) (gdal.Geometry, bool) {
+	xyLinearRing := gdal.Create(gdal.GT_LinearRing)
+	nPoints := 0
+	for _, idx := range facePointIdxs {
+		// facepoint indexes are padded with -1
+		if idx < 0 || int(idx) >= len(facePoints) {
+			continue
+		}
+		xyLinearRing.AddPoint2D(facePoints[int(idx)][0], facePoints[int(idx)][1])
+		nPoints++
+	}
+	// perimeter (ghost) cells do not have a polygon
+	if nPoints < 3 {
+		xyLinearRing.Destroy()
+		return xyLinearRing, false
+	}
+	xyLinearRing.CloseRings()
+
+	xyLinearRing.Transform(transform)
+	// The x and y values need to be flipped
+	yxLinearRing := flipXYLinearRing(xyLinearRing)
+
+	yxPolygon := gdal.Create(gdal.GT_Polygon)
+	yxPolygon.AddGeometry(yxLinearRing)
+	return yxPolygon.ForceToMultiPolygon(), true
+}
+
+// hdfMeshLine creates a multilinestring from the facepoints and perimeter points of a mesh face
+func hdfMeshLine(xyPairs [][]float64, transform gdal.CoordinateTransform) gdal.Geometry {
+	xyLineString := gdal.Create(gdal.GT_LineString)
+	for _, pair := range xyPairs {
+		xyLineString.AddPoint2D(pair[0], pair[1])
+	}
+
+	xyLineString.Transform(transform)
+	// The x and y values need to be flipped
+	yxLineString := flipXYLineString(xyLineString)
+	return yxLineString.ForceToMultiLineString()
+}
+
+// errMissingMeshDataset is returned when the geometry HDF5 file does not hold the complete mesh of a 2D area,
+// e.g. the geometry was not preprocessed by the HEC-RAS version that wrote the file
+var errMissingMeshDataset = errors.New("dataset is missing from the geometry HDF5 file")
+
+// Extract mesh cells, faces and cell centers of a 2D area from the geometry HDF5 file.
+// Unlike getMeshArea, cells and faces are the exact ones computed by HEC-RAS.
+// Features have the MeshSource field 'hdf': mesh_points (cell centers), mesh_cell and mesh_face.
+func getHDFMeshArea(h hdfFile, area string, transform gdal.CoordinateTransform) ([]VectorFeature, error) {
+	features := []VectorFeature{}
+	group := fmt.Sprintf("%s/%s", twoDGeometryGroup, area)
+
+	// only curved faces have perimeter values
+	optional := []string{"Faces Perimeter Info", "Faces Perimeter Values"}
+	datasets := map[string][][]float64{}
+	for _, name := range []string{"Cells Center Coordinate", "Cells FacePoint Indexes", "Cells Minimum Elevation",
+		"FacePoints Coordinate", "Faces FacePoint Indexes", "Faces Minimum Elevation", "Faces Perimeter Info", "Faces Perimeter Values"} {
+		path := fmt.Sprintf("%s/%s", group, name)
+		if !h.hasDataset(path) {
+			if stringInSlice(name, optional) {
+				continue
+			}
+			return features, errors.WrapPrefix(errMissingMeshDataset, path, 0)
+		}
+		rows, err := h.readDataset(path)
+		if err != nil {
+			return features, errors.Wrap(err, 0)
+		}
+		datasets[name] = rows
+	}
+
+	centers, facePoints := datasets["Cells Center Coordinate"], datasets["FacePoints Coordinate"]
+	cellMinElev, faceMinElev := []float64{}, []float64{}
+	if rows := datasets["Cells Minimum Elevation"]; len(rows) > 0 {
+		cellMinElev = rows[0]
+	}
+	if rows := datasets["Faces Minimum Elevation"]; len(rows) > 0 {
+		faceMinElev = rows[0]
+	}
+
+	// Cell centers
+	multipoint := gdal.Create(gdal.GT_MultiPoint)
+	for _, center := range centers {
+		xyPoint := gdal.Create(gdal.GT_Point)
+		xyPoint.AddPoint2D(center[0], center[1])
+		xyPoint.Transform(transform)
+		// The x and y values need to be flipped
+		if err := multipoint.AddGeometry(flipXYPoint(xyPoint)); err != nil {
+			return features, errors.Wrap(err, 0)
+		}
+	}
+	wkb, err := multipoint.ToWKB()
+	if err != nil {
+		return features, errors.Wrap(err, 0)
+	}
+	features = append(features, VectorFeature{FeatureName: "mesh_points", Fields: map[string]interface{}{"Area": area, "MeshSource": "hdf"}, Geometry: wkb})
+
+	// Cells
+	for cellID, facePointIdxs := range datasets["Cells FacePoint Indexes"] {
+		polygon, ok := hdfMeshPolygon(facePointIdxs, facePoints, transform)
+		if !ok {
+			continue
+		}
+		wkb, err := polygon.ToWKB()
+		if err != nil {
+			return features, errors.Wrap(err, 0)
+		}
+		feature := VectorFeature{FeatureName: "mesh_cell", Fields: map[string]interface{}{"Area": area, "MeshSource": "hdf", "CellID": cellID}, Geometry: wkb}
+		// NaN cannot be encoded to json
+		if cellID < len(cellMinElev) && !math.IsNaN(cellMinElev[cellID]) {
+			feature.Fields["MinElevation"] = cellMinElev[cellID]
+		}
+		features = append(features, feature)
+	}
+
+	// Faces
+	perimeterInfo, perimeterValues := datasets["Faces Perimeter Info"], datasets["Faces Perimeter Values"]
+	for faceID, facePointIdxs := range datasets["Faces FacePoint Indexes"] {
+		if len(facePointIdxs) < 2 || int(facePointIdxs[0]) >= len(facePoints) || int(facePointIdxs[1]) >= len(facePoints) {
+			continue
+		}
+		xyPairs := [][]float64{facePoints[int(facePointIdxs[0])]}
+		// curved faces store their interior points in the perimeter values
+		if faceID < len(perimeterInfo) {
+			start, count := int(perimeterInfo[faceID][0]), int(perimeterInfo[faceID][1])
+			if count > 0 && start >= 0 && start+count <= len(perimeterValues) {
+				xyPairs = append(xyPairs, perimeterValues[start:start+count]...)
+			}
+		}
+		xyPairs = append(xyPairs, facePoints[int(facePointIdxs[1])])
+
+		wkb, err := hdfMeshLine(xyPairs, transform).ToWKB()
+		if err != nil {
+			return features, errors.Wrap(err, 0)
+		}
+		feature := VectorFeature{FeatureName: "mesh_face", Fields: map[string]interface{}{"Area": area, "MeshSource": "hdf", "FaceID": faceID}, Geometry: wkb}
+		if faceID < len(faceMinElev) && !math.IsNaN(faceMinElev[faceID]) {
+			feature.Fields["MinElevation"] = faceMinElev[faceID]
+		}
+		features = append(features, feature)
+	}
+
+	return features, nil
+}
+
 func getAreaType(sc *bufio.Scanner) (string, error) {
 	is2D := ""
 
@@ -544,7 +1185,106 @@ func getBCArea(sc *bufio.Scanner) (string, error) {
 	return "", errors.New("Failed to parse BC Line Storage Area.")
 }
 
-// Extract name and geometry from Connection text block and return as Vector Feature
+// Extract the lateral weir centerline of a lateral structure text block and return as Vector Feature named by its river station
+func getLateralStructureLine(sc *bufio.Scanner, transform gdal.CoordinateTransform, riverReachName string, station string) (VectorFeature, error) {
+	feature := VectorFeature{FeatureName: station, Fields: map[string]interface{}{}}
+	feature.Fields["RiverReachName"] = riverReachName
+
+	xyPairs, err := getDataPairsfromTextBlock("Lateral Weir Centerline=", sc, 64, 16)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+
+	// If less than 2 xyPairs, it is not a valid line.
+	if len(xyPairs) < 2 {
+		return feature, errors.New("Invalid Line Geometry")
+	}
+
+	xyLineString := gdal.Create(gdal.GT_LineString)
+	for _, pair := range xyPairs {
+		xyLineString.AddPoint2D(pair[0], pair[1])
+	}
+
+	xyLineString.Transform(transform)
+	// The x and y values need to be flipped
+	yxLineString := flipXYLineString(xyLineString)
+
+	multiLineString := yxLineString.ForceToMultiLineString()
+
+	wkb, err := multiLineString.ToWKB()
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	feature.Geometry = wkb
+	return feature, nil
+}
+
+// Get junction location e.g. 'Junct X Y & Text X Y=2017867.42,364836.37,2017867.42,364836.37'
+func getJunctionPoint(line string, transform gdal.CoordinateTransform, junctionName string) (VectorFeature, error) {
+	feature := VectorFeature{FeatureName: junctionName, Fields: map[string]interface{}{}}
+	feature.Fields["Up Reaches"] = []string{}
+	feature.Fields["Dn Reaches"] = []string{}
+
+	lineData := strings.Split(rightofEquals(line), ",")
+	if len(lineData) < 2 || strings.TrimSpace(lineData[0]) == "" || strings.TrimSpace(lineData[1]) == "" {
+		return feature, errors.New("Invalid Point Geometry")
+	}
+	x, err := parseFloat(strings.TrimSpace(lineData[0]), 64)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	y, err := parseFloat(strings.TrimSpace(lineData[1]), 64)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+
+	xyPoint := gdal.Create(gdal.GT_Point)
+	xyPoint.AddPoint2D(x, y)
+	xyPoint.Transform(transform)
+	// The x and y values need to be flipped
+	yxPoint := flipXYPoint(xyPoint)
+	multiPoint := yxPoint.ForceToMultiPoint()
+
+	wkb, err := multiPoint.ToWKB()
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	feature.Geometry = wkb
+	return feature, nil
+}
+
+// Get pump station location e.g. 'Pump Station=Pump Station #1,2034567.12,345678.9'
+func getPumpStationPoint(line string, transform gdal.CoordinateTransform) (VectorFeature, error) {
+	lineData := strings.Split(rightofEquals(line), ",")
+	feature := VectorFeature{FeatureName: strings.TrimSpace(lineData[0]), Fields: map[string]interface{}{}}
+
+	if len(lineData) < 3 || strings.TrimSpace(lineData[1]) == "" || strings.TrimSpace(lineData[2]) == "" {
+		return feature, errors.New("Invalid Point Geometry")
+	}
+	x, err := parseFloat(strings.TrimSpace(lineData[1]), 64)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	y, err := parseFloat(strings.TrimSpace(lineData[2]), 64)
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+
+	xyPoint := gdal.Create(gdal.GT_Point)
+	xyPoint.AddPoint2D(x, y)
+	xyPoint.Transform(transform)
+	// The x and y values need to be flipped
+	yxPoint := flipXYPoint(xyPoint)
+	multiPoint := yxPoint.ForceToMultiPoint()
+
+	wkb, err := multiPoint.ToWKB()
+	if err != nil {
+		return feature, errors.Wrap(err, 0)
+	}
+	feature.Geometry = wkb
+	return feature, nil
+}
+
 func getConnectionLine(sc *bufio.Scanner, transform gdal.CoordinateTransform) (VectorFeature, error) {
 	feature := VectorFeature{
 		FeatureName: strings.TrimSpace(strings.Split(rightofEquals(sc.Text()), ",")[0]),
@@ -607,11 +1347,23 @@ func getConnArea(sc *bufio.Scanner) (string, string, error) {
 	return "", "", errors.New("Failed to parse Connection Up/Dn Areas.")
 }
 
-// GetGeospatialData ...
-func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string, sourceCRS string, destinationCRS int) error {
+// GetGeospatialData reads the features of a geometry file, fileList is used to check if the geometry HDF5 file exists
+func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string, fileList []string, sourceCRS string, destinationCRS string) error {
 	geomFileName := filepath.Base(geomFilePath)
 	f := Features{}
 	riverReachName := ""
+	areaName := ""
+	var xs *xsCutLine
+	// structures are located between the last cross section read and the next one
+	var lastXS *xsCutLine
+	structureLocs := []*structureLocation{}
+	pendingStructures := []*structureLocation{}
+	// structure whose lines are being collected
+	var structureLoc *structureLocation
+	centerlines := make(map[string][][2]float64)
+	lateralStation := ""
+	pumpStationName := ""
+	junctionName := ""
 
 	file, err := fs.GetObject(geomFilePath)
 	if err != nil {
@@ -626,17 +1378,56 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 		return errors.Wrap(err, 0)
 	}
 
+	// 2D meshes are read from the geometry HDF5 file when it exists, it is only opened once a 2D area is found
+	var meshHDF hdfFile
+	hasMeshHDF, meshHDFChecked := false, false
+	defer func() {
+		if hasMeshHDF {
+			meshHDF.Close()
+		}
+	}()
+
+	// adds the hydraulic attribute layers of the last cross section read
+	addXSAttributeLayers := func() error {
+		if xs == nil {
+			return nil
+		}
+		ineffLayer, leveeLayer, blockLayer, err := getXSAttributeLayers(*xs, transform)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		f.IneffectiveAreas = append(f.IneffectiveAreas, ineffLayer...)
+		f.Levees = append(f.Levees, leveeLayer...)
+		f.BlockedObstructions = append(f.BlockedObstructions, blockLayer...)
+		xs = nil
+		return nil
+	}
+
 	for sc.Scan() {
 		line := sc.Text()
 
+		// the lines of a cross section or hydraulic structure end at the next river element
+		if strings.HasPrefix(line, "Type RM Length L Ch R") || strings.HasPrefix(line, "River Reach=") || strings.HasPrefix(line, "Storage Area=") {
+			if err := addXSAttributeLayers(); err != nil {
+				return errors.Wrap(err, 0)
+			}
+			structureLoc = nil
+		}
+		if structureLoc != nil {
+			structureLoc.lines = append(structureLoc.lines, line)
+		}
+
 		switch {
 		case strings.HasPrefix(line, "River Reach="):
-			riverFeature, err := getRiverCenterline(sc, transform)
+			riverFeature, centerline, err := getRiverCenterline(sc, transform)
 			if err != nil {
 				return errors.Wrap(err, 0)
 			}
 			f.Rivers = append(f.Rivers, riverFeature)
 			riverReachName = riverFeature.FeatureName
+			centerlines[riverReachName] = centerline
+			lastXS = nil
+			pendingStructures = []*structureLocation{}
 
 		case strings.HasPrefix(line, "Storage Area="):
 			storageAreaFeature, aType, err := getArea(sc, transform)
@@ -648,21 +1439,88 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 			} else if aType == "-1" {
 				f.TwoDAreas = append(f.TwoDAreas, storageAreaFeature)
 			}
+			areaName = storageAreaFeature.FeatureName
+
 		case strings.HasPrefix(line, "Storage Area 2D Points="):
+			if !meshHDFChecked && stringInSlice(geomFilePath+".hdf", fileList) {
+				meshHDF, err = openHDF(fs, geomFilePath+".hdf")
+				if err != nil {
+					log.Println("Mesh|", err)
+				}
+				hasMeshHDF = err == nil
+			}
+			meshHDFChecked = true
+
+			if hasMeshHDF {
+				meshFeatures, err := getHDFMeshArea(meshHDF, areaName, transform)
+				if err == nil {
+					f.Mesh = append(f.Mesh, meshFeatures...)
+					continue
+				}
+				if !errors.Is(err, errMissingMeshDataset) {
+					return errors.Wrap(err, 0)
+				}
+				log.Println("Mesh|", areaName, err)
+			}
+			// fall back to approximating the mesh from the cell centers
 			epsilon := 1e-1
-			meshFeatures, err := getMeshArea(sc, transform, epsilon)
+			meshFeatures, err := getMeshArea(sc, areaName, transform, epsilon)
 			if err != nil {
 				return errors.Wrap(err, 0)
 			}
 			f.Mesh = append(f.Mesh, meshFeatures...)
 
 		case strings.HasPrefix(line, "Type RM Length L Ch R = 1"):
-			xsFeature, bankLayer, err := getXSBanks(sc, transform, riverReachName)
+			xsLine, bankLayer, err := getXSBanks(sc, transform, riverReachName)
 			if err != nil {
 				return errors.Wrap(err, 0)
 			}
-			f.XS = append(f.XS, xsFeature)
+			f.XS = append(f.XS, xsLine.feature)
 			f.Banks = append(f.Banks, bankLayer...)
+			xs = &xsLine
+
+			for _, loc := range pendingStructures {
+				loc.downstreamXS = xs
+			}
+			pendingStructures = []*structureLocation{}
+			lastXS = xs
+
+		case strings.HasPrefix(line, "Type RM Length L Ch R = 6"):
+			lateralStation = strings.TrimSpace(strings.Split(rightofEquals(line), ",")[1])
+
+		case strings.HasPrefix(line, "Lateral Weir Centerline="):
+			lateralFeature, err := getLateralStructureLine(sc, transform, riverReachName, lateralStation)
+			switch {
+			case err != nil:
+				switch {
+				case err.Error() == "Invalid Line Geometry":
+					log.Println("Skipped", lateralFeature.FeatureName, err.Error(), "Geom File:", filepath.Ext(geomFilePath))
+				default:
+					return errors.Wrap(err, 0)
+				}
+			default:
+				f.LateralStructures = append(f.LateralStructures, lateralFeature)
+			}
+
+		case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			data := strings.Split(rightofEquals(line), ",")
+			structureType, ok := structureTypes[strings.TrimSpace(data[0])]
+			if !ok {
+				continue
+			}
+			station, err := parseFloat(strings.TrimSpace(data[1]), 64)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			loc := structureLocation{riverReachName: riverReachName, structureType: structureType, station: station, lineData: data, upstreamXS: lastXS}
+			structureLocs = append(structureLocs, &loc)
+			pendingStructures = append(pendingStructures, &loc)
+			structureLoc = &loc
+
+		case xs != nil && isXSAttribute(line):
+			if err := getXSAttributes(sc, xs.feature); err != nil {
+				return errors.Wrap(err, 0)
+			}
 
 		case strings.HasPrefix(line, "BreakLine Name="):
 			blFeature, err := getBreakLine(sc, transform)
@@ -692,6 +1550,63 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 				f.BCLines = append(f.BCLines, bcFeature)
 			}
 
+		case strings.HasPrefix(line, "Junct Name="):
+			junctionName = rightofEquals(line)
+
+		case strings.HasPrefix(line, "Junct X Y & Text X Y="):
+			junctFeature, err := getJunctionPoint(line, transform, junctionName)
+			switch {
+			case err != nil:
+				switch {
+				case err.Error() == "Invalid Point Geometry":
+					log.Println("Skipped", junctFeature.FeatureName, err.Error(), "Geom File:", filepath.Ext(geomFilePath))
+				default:
+					return errors.Wrap(err, 0)
+				}
+			default:
+				f.Junctions = append(f.Junctions, junctFeature)
+			}
+
+		case strings.HasPrefix(line, "Up River,Reach="), strings.HasPrefix(line, "Dn River,Reach="):
+			// skipped junctions do not have a feature
+			if len(f.Junctions) > 0 && f.Junctions[len(f.Junctions)-1].FeatureName == junctionName {
+				junct := f.Junctions[len(f.Junctions)-1]
+				key := "Up Reaches"
+				if strings.HasPrefix(line, "Dn") {
+					key = "Dn Reaches"
+				}
+				junct.Fields[key] = append(junct.Fields[key].([]string), getJunctionReach(line).riverReachName())
+			}
+
+		case strings.HasPrefix(line, "Pump Station="):
+			pumpFeature, err := getPumpStationPoint(line, transform)
+			pumpStationName = pumpFeature.FeatureName
+			switch {
+			case err != nil:
+				switch {
+				case err.Error() == "Invalid Point Geometry":
+					log.Println("Skipped", pumpFeature.FeatureName, err.Error(), "Geom File:", filepath.Ext(geomFilePath))
+				default:
+					return errors.Wrap(err, 0)
+				}
+			default:
+				f.PumpStations = append(f.PumpStations, pumpFeature)
+			}
+
+		case strings.HasPrefix(line, "Pump From Location="):
+			// skipped pump stations do not have a feature
+			if len(f.PumpStations) > 0 && f.PumpStations[len(f.PumpStations)-1].FeatureName == pumpStationName {
+				pump := f.PumpStations[len(f.PumpStations)-1]
+				pump.Fields["From Type"], pump.Fields["From"] = pumpLocation(line)
+			}
+
+		case strings.HasPrefix(line, "Pump To Location="):
+			// skipped pump stations do not have a feature
+			if len(f.PumpStations) > 0 && f.PumpStations[len(f.PumpStations)-1].FeatureName == pumpStationName {
+				pump := f.PumpStations[len(f.PumpStations)-1]
+				pump.Fields["To Type"], pump.Fields["To"] = pumpLocation(line)
+			}
+
 		case strings.HasPrefix(line, "Connection="):
 			connFeature, err := getConnectionLine(sc, transform)
 			switch {
@@ -708,6 +1623,22 @@ func GetGeospatialData(gd *GeoData, fs filestore.FileStore, geomFilePath string,
 
 		}
 	}
+	if err := addXSAttributeLayers(); err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	for _, loc := range structureLocs {
+		centerline := centerlines[loc.riverReachName]
+		if len(centerline) < 2 {
+			log.Println("Skipped", loc.structureType, loc.station, "no reach centerline", "Geom File:", filepath.Ext(geomFilePath))
+			continue
+		}
+		structureFeature, err := getStructureFeature(*loc, centerline, transform)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		f.HydraulicStructures = append(f.HydraulicStructures, structureFeature)
+	}
 
 	gd.Features[geomFileName] = f
 	return nil
@@ -0,0 +1,198 @@
+package tools
+
+import (
+	"bufio"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dewberry/gdal"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+func TestGetHDFMeshAreaMissingMinElevation(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	_, err := getHDFMeshArea(h, "Perimeter 2", gdal.CoordinateTransform{})
+	if err == nil {
+		t.Fatal("expected an error for the missing cells minimum elevation")
+	}
+	if !strings.Contains(err.Error(), "Cells Minimum Elevation") {
+		t.Errorf("error should name the missing dataset, got %v", err)
+	}
+	// the mesh is approximated from the cell centers instead
+	if !errors.Is(err, errMissingMeshDataset) {
+		t.Errorf("got %v, want errMissingMeshDataset", err)
+	}
+}
+
+// Read the hydraulic attributes of every cross section of a geometry file
+func readTestXSAttributes(t *testing.T, fn string) []VectorFeature {
+	t.Helper()
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	features := []VectorFeature{}
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "Type RM Length L Ch R = 1"):
+			rs := strings.TrimSpace(strings.Split(rightofEquals(line), ",")[1])
+			features = append(features, VectorFeature{FeatureName: rs, Fields: map[string]interface{}{}})
+
+		case len(features) > 0 && isXSAttribute(line):
+			if err := getXSAttributes(sc, features[len(features)-1]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	return features
+}
+
+func TestGetXSAttributes(t *testing.T) {
+	features := readTestXSAttributes(t, "testdata/XSAttributes.g01")
+	if len(features) != 2 {
+		t.Fatalf("got %d cross sections, want 2", len(features))
+	}
+
+	want := map[string]interface{}{
+		"ManningsN": []XSManningsN{{0, 0.06}, {190, 0.035}, {375, 0.06}},
+		"IneffectiveAreas": []XSStationBlock{
+			{StartStation: 0, EndStation: 100, Elevation: 950.5, Permanent: true},
+			{StartStation: 400, EndStation: 500, Elevation: 951},
+		},
+		"BlockedObstructions":    []XSStationBlock{{StartStation: 450, EndStation: 500, Elevation: 952.5}},
+		"Levees":                 map[string]XSLevee{"Left": {190, 955.5}, "Right": {375, 956}},
+		"ExpansionCoefficient":   0.3,
+		"ContractionCoefficient": 0.1,
+	}
+	if got := features[0].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestGetXSAttributesZeroCounts(t *testing.T) {
+	features := readTestXSAttributes(t, "testdata/XSAttributes.g01")
+	if len(features) != 2 {
+		t.Fatalf("got %d cross sections, want 2", len(features))
+	}
+
+	// lines following a zero count belong to the next attribute and must not be read as values
+	want := map[string]interface{}{
+		"IneffectiveAreas":       []XSStationBlock{},
+		"BlockedObstructions":    []XSStationBlock{},
+		"ExpansionCoefficient":   0.5,
+		"ContractionCoefficient": 0.3,
+	}
+	if got := features[1].Fields; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+// readTestStructureLocations collects the lines of each hydraulic structure of a geometry file like GetGeospatialData
+func readTestStructureLocations(t *testing.T, fn string) []*structureLocation {
+	f, err := os.Open(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	locs := []*structureLocation{}
+	var loc *structureLocation
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			loc = nil
+			data := strings.Split(rightofEquals(line), ",")
+			if structureType, ok := structureTypes[strings.TrimSpace(data[0])]; ok {
+				loc = &structureLocation{riverReachName: "White, Muncie", structureType: structureType, lineData: data}
+				locs = append(locs, loc)
+			}
+		case strings.HasPrefix(line, "River Reach="):
+			loc = nil
+		case loc != nil:
+			loc.lines = append(loc.lines, line)
+		}
+	}
+	return locs
+}
+
+func TestGetStructureFields(t *testing.T) {
+	locs := readTestStructureLocations(t, "testdata/Structures.g01")
+	if len(locs) != 3 {
+		t.Fatalf("got %d structures, want 3", len(locs))
+	}
+
+	want := []map[string]interface{}{
+		{
+			"RiverReachName": "White, Muncie",
+			"Type":           "Bridge",
+			"Name":           "Main St Bridge",
+			"DeckWidth":      20.0,
+			"UpHighChord":    maxMinPairs{962, 960},
+			"UpLowChord":     maxMinPairs{955, 954},
+			"DownHighChord":  maxMinPairs{961, 959},
+			"DownLowChord":   maxMinPairs{954, 953},
+			"NumPiers":       2,
+		},
+		{
+			"RiverReachName": "White, Muncie",
+			"Type":           "Culvert",
+			"Name":           "Culvert Crossing",
+			"DeckWidth":      25.0,
+			"UpHighChord":    maxMinPairs{958, 957},
+			"UpLowChord":     maxMinPairs{950, 950},
+			"DownHighChord":  maxMinPairs{957, 956},
+			"DownLowChord":   maxMinPairs{949, 949},
+			"NumConduits":    2,
+		},
+		{
+			"RiverReachName": "White, Muncie",
+			"Type":           "Inline Weir",
+			"Name":           "Spillway",
+			"WeirWidth":      25.0,
+			"WeirElev":       maxMinPairs{951, 950},
+			"NumGates":       1,
+			"NumConduits":    0,
+		},
+	}
+	for i, loc := range locs {
+		fields, err := getStructureFields(*loc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(fields, want[i]) {
+			t.Errorf("got %+v\nwant %+v", fields, want[i])
+		}
+	}
+}
+
+func TestStructureCenterlineDistance(t *testing.T) {
+	// centerline drawn from upstream to downstream, crossed by the cut lines of stations 1000 and 900
+	centerline := [][2]float64{{0, 1000}, {0, 0}}
+	upstreamXS := &xsCutLine{feature: VectorFeature{FeatureName: "1000"}, xyPairs: [][2]float64{{-100, 800}, {100, 800}}}
+	downstreamXS := &xsCutLine{feature: VectorFeature{FeatureName: "900"}, xyPairs: [][2]float64{{-100, 600}, {100, 600}}}
+
+	for _, tc := range []struct {
+		name string
+		loc  structureLocation
+		want float64
+	}{
+		{"between cross sections", structureLocation{station: 950, upstreamXS: upstreamXS, downstreamXS: downstreamXS}, 300},
+		{"upstream cross section only", structureLocation{station: 950, upstreamXS: upstreamXS}, 250},
+		{"downstream cross section only", structureLocation{station: 950, downstreamXS: downstreamXS}, 350},
+		{"no cross sections", structureLocation{station: 950}, 50},
+		{"past the upstream end", structureLocation{station: 2000}, 0},
+	} {
+		if got := structureCenterlineDistance(tc.loc, centerline); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+// Structs and functions used to read HEC-RAS HDF5 files through GDAL's HDF5 driver.
+
+package tools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/USACE/filestore"
+	"github.com/dewberry/gdal"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// HDF5 file opened with GDAL.
+// GDAL exposes HDF5 attributes as metadata items and HDF5 datasets of two or more dimensions as subdatasets,
+// every dataset is listed once when the file is opened using the multidimensional API
+type hdfFile struct {
+	name        string // path of the file opened by GDAL
+	dataset     gdal.Dataset
+	metadata    map[string]string // normalized attribute path: value
+	subdatasets map[string]string // normalized dataset path: subdataset name
+	paths       map[string]string // normalized dataset path: dataset path as stored in the file
+}
+
+// gdalPath returns a path that GDAL can open for a file in the filestore,
+// objects in s3 are read through a presigned url of the filestore's bucket
+func gdalPath(fs filestore.FileStore, fn string) (string, error) {
+	switch s3fs := fs.(type) {
+	case *filestore.S3FS:
+		url, err := s3fs.SharedAccessURL(fn, time.Hour)
+		if err != nil {
+			return "", errors.Wrap(err, 0)
+		}
+		return "/vsicurl/" + url, nil
+	}
+	return fn, nil
+}
+
+// hdfKey normalizes HDF5 paths and GDAL metadata keys so they can be compared,
+// e.g. 'Results/Unsteady/Summary' and 'Results_Unsteady_Summary' both become 'results_unsteady_summary'
+func hdfKey(path string) string {
+	return strings.Trim(strings.ToLower(strings.NewReplacer(" ", "_", "/", "_").Replace(path)), "_")
+}
+
+// openHDF opens an HDF5 file and indexes its attributes and datasets
+func openHDF(fs filestore.FileStore, fn string) (hdfFile, error) {
+	h := hdfFile{
+		metadata:    make(map[string]string),
+		subdatasets: make(map[string]string),
+		paths:       make(map[string]string),
+	}
+
+	name, err := gdalPath(fs, fn)
+	if err != nil {
+		return h, errors.Wrap(err, 0)
+	}
+	h.name = name
+	ds, err := gdal.OpenEx(h.name, gdal.OFReadOnly|gdal.OFRaster, []string{"HDF5"}, nil, nil)
+	if err != nil {
+		return h, errors.Wrap(err, 0)
+	}
+	h.dataset = ds
+
+	for _, item := range ds.Metadata("") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) == 2 {
+			h.metadata[hdfKey(kv[0])] = kv[1]
+		}
+	}
+
+	for _, item := range ds.Metadata("SUBDATASETS") {
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 || !strings.HasSuffix(kv[0], "_NAME") {
+			continue
+		}
+		// subdataset names look like HDF5:"file.hdf"://Results/Unsteady/...
+		idx := strings.LastIndex(kv[1], "\":")
+		if idx == -1 {
+			continue
+		}
+		h.subdatasets[hdfKey(kv[1][idx+2:])] = kv[1]
+	}
+
+	paths, err := h.datasetPaths()
+	if err != nil {
+		ds.Close()
+		return h, errors.Wrap(err, 0)
+	}
+	for _, path := range paths {
+		h.paths[hdfKey(path)] = path
+	}
+
+	return h, nil
+}
+
+// Close the underlying GDAL dataset
+func (h hdfFile) Close() {
+	h.dataset.Close()
+}
+
+// attribute returns the value of an HDF5 attribute given its group path and name,
+// attributes of the root group have an empty group path
+func (h hdfFile) attribute(group string, name string) (string, bool) {
+	if val, ok := h.metadata[hdfKey(group+"/"+name)]; ok {
+		return strings.TrimSpace(val), true
+	}
+	return "", false
+}
+
+// attributes returns the values of every HDF5 attribute with the given name found under a group,
+// e.g. the source raster 'File' of each terrain tile under 'Terrain'
+func (h hdfFile) attributes(group string, name string) []string {
+	values := []string{}
+	prefix, suffix := hdfKey(group)+"_", "_"+hdfKey(name)
+	for key, val := range h.metadata {
+		if strings.HasPrefix(key, prefix) && strings.HasSuffix(key, suffix) {
+			values = append(values, strings.TrimSpace(val))
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+// floatAttribute returns the value of a numeric HDF5 attribute
+func (h hdfFile) floatAttribute(group string, name string) (float64, bool) {
+	val, ok := h.attribute(group, name)
+	if !ok {
+		return 0, false
+	}
+	f, err := parseFloat(val, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// datasetName returns the name GDAL opens a dataset with.
+// One dimensional datasets are not listed as subdatasets, they are opened directly using the same naming
+// as subdatasets where spaces in the path are replaced by underscores e.g. HDF5:"file.hdf"://Geometry/2D_Flow_Areas/...
+func (h hdfFile) datasetName(path string) string {
+	if name, ok := h.subdatasets[hdfKey(path)]; ok {
+		return name
+	}
+	return fmt.Sprintf("HDF5:\"%s\"://%s", h.name, strings.ReplaceAll(strings.Trim(path, "/"), " ", "_"))
+}
+
+// hasDataset checks if a dataset exists in the HDF5 file
+func (h hdfFile) hasDataset(path string) bool {
+	_, ok := h.paths[hdfKey(path)]
+	return ok
+}
+
+// childGroups returns the names of the groups directly under the given group that contain datasets,
+// e.g. the 2D Flow Area names under 'Geometry/2D Flow Areas'
+func (h hdfFile) childGroups(group string) []string {
+	groups := []string{}
+	prefix := hdfKey(group) + "_"
+	depth := strings.Count(strings.Trim(group, "/"), "/") + 1
+	for key, path := range h.paths {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		parts := strings.Split(path, "/")
+		if len(parts) > depth+1 && !stringInSlice(parts[depth], groups) {
+			groups = append(groups, parts[depth])
+		}
+	}
+	return groups
+}
+
+// readDataset reads an entire numeric HDF5 dataset as rows of values
+func (h hdfFile) readDataset(path string) ([][]float64, error) {
+	rows := [][]float64{}
+
+	ds, err := gdal.Open(h.datasetName(path), gdal.ReadOnly)
+	if err != nil {
+		return rows, errors.Errorf("dataset %s does not exist or cannot be read: %v", path, err)
+	}
+	defer ds.Close()
+
+	xSize, ySize := ds.RasterXSize(), ds.RasterYSize()
+	if xSize == 0 || ySize == 0 {
+		return rows, nil
+	}
+
+	buffer := make([]float64, xSize*ySize)
+	if err := ds.RasterBand(1).IO(gdal.Read, 0, 0, xSize, ySize, buffer, xSize, ySize, 0, 0); err != nil {
+		return rows, errors.Wrap(err, 0)
+	}
+
+	for r := 0; r < ySize; r++ {
+		rows = append(rows, buffer[r*xSize:(r+1)*xSize])
+	}
+	return rows, nil
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+// Open an HDF5 file of testdata, the fixtures are written by testdata/make_hdf.py
+func openTestHDF(t *testing.T, fn string) hdfFile {
+	t.Helper()
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := openHDF(fs, "testdata/"+fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(h.Close)
+	return h
+}
+
+func TestHDFKey(t *testing.T) {
+	for _, path := range []string{"Results/Unsteady/Summary", "/Results/Unsteady/Summary", "Results_Unsteady_Summary"} {
+		if got := hdfKey(path); got != "results_unsteady_summary" {
+			t.Errorf("hdfKey(%q) = %q", path, got)
+		}
+	}
+}
+
+func TestHDFAttribute(t *testing.T) {
+	h := hdfFile{metadata: map[string]string{
+		hdfKey("Projection"):                        "PROJCS[...]",
+		hdfKey("Results_Unsteady_Summary_Solution"): " Unsteady Finished Successfully ",
+	}}
+
+	if val, ok := h.attribute("", "Projection"); !ok || val != "PROJCS[...]" {
+		t.Errorf("root attribute: got %q, %v", val, ok)
+	}
+	if val, ok := h.attribute("Results/Unsteady/Summary", "Solution"); !ok || val != "Unsteady Finished Successfully" {
+		t.Errorf("group attribute: got %q, %v", val, ok)
+	}
+	if _, ok := h.attribute("Results/Unsteady", "Projection"); ok {
+		t.Error("attribute of another group should not match")
+	}
+}
+
+func TestHDFDatasetName(t *testing.T) {
+	listed := `HDF5:"plan.p01.hdf"://Results/Unsteady/Output/Output_Blocks/Base_Output/Summary_Output/2D_Flow_Areas/Perimeter_1/Maximum_Face_Velocity`
+	h := hdfFile{
+		name:        "plan.p01.hdf",
+		subdatasets: map[string]string{hdfKey("Results/Unsteady/Output/Output_Blocks/Base_Output/Summary_Output/2D_Flow_Areas/Perimeter_1/Maximum_Face_Velocity"): listed},
+	}
+
+	if got := h.datasetName("Results/Unsteady/Output/Output Blocks/Base Output/Summary Output/2D Flow Areas/Perimeter 1/Maximum Face Velocity"); got != listed {
+		t.Errorf("listed subdataset: got %s", got)
+	}
+	want := `HDF5:"plan.p01.hdf"://Geometry/2D_Flow_Areas/Perimeter_1/Cells_Minimum_Elevation`
+	if got := h.datasetName("/Geometry/2D Flow Areas/Perimeter 1/Cells Minimum Elevation"); got != want {
+		t.Errorf("one dimensional dataset: got %s, want %s", got, want)
+	}
+}
+
+func TestGDALPath(t *testing.T) {
+	blockFS, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := gdalPath(blockFS, "/models/Muncie/Muncie.p04.hdf"); err != nil || got != "/models/Muncie/Muncie.p04.hdf" {
+		t.Errorf("local file: got %s, %v", got, err)
+	}
+
+	// presigning does not connect to s3
+	s3FS, err := filestore.NewFileStore(filestore.S3FSConfig{S3Id: "id", S3Key: "key", S3Region: "us-east-1", S3Bucket: "ras-models"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := gdalPath(s3FS, "/models/Muncie/Muncie.p04.hdf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "/vsicurl/https://") || !strings.Contains(got, "ras-models") || !strings.Contains(got, "/models/Muncie/Muncie.p04.hdf?") {
+		t.Errorf("s3 object: got %s", got)
+	}
+}
+
+func TestOpenHDF(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	if val, ok := h.attribute("", "File Type"); !ok || val != "HEC-RAS Results" {
+		t.Errorf("root attribute: got %q, %v", val, ok)
+	}
+	if val, ok := h.floatAttribute(volumeAccountingGroup, "Error Percent"); !ok || val != 0.5 {
+		t.Errorf("numeric attribute: got %v, %v", val, ok)
+	}
+
+	areas := h.childGroups(summaryOutputGroup + "/2D Flow Areas")
+	sort.Strings(areas)
+	if want := []string{"Perimeter 1", "Perimeter 2"}; !reflect.DeepEqual(areas, want) {
+		t.Errorf("got 2D areas %v, want %v", areas, want)
+	}
+
+	// one dimensional datasets are not GDAL subdatasets
+	elevPath := twoDGeometryGroup + "/Perimeter 1/Cells Minimum Elevation"
+	if !h.hasDataset(elevPath) {
+		t.Fatalf("%s not found", elevPath)
+	}
+	rows, err := h.readDataset(elevPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]float64{{948, 946, 946, 942}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("one dimensional dataset: got %v, want %v", rows, want)
+	}
+
+	wsPath := summaryOutputGroup + "/Cross Sections/Maximum Water Surface"
+	rows, err = h.readDataset(wsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]float64{{952.5, 951.25, 950}, {0.5, 0.75, 1}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("two dimensional dataset: got %v, want %v", rows, want)
+	}
+
+	missingPath := twoDGeometryGroup + "/Perimeter 2/Cells Minimum Elevation"
+	if h.hasDataset(missingPath) {
+		t.Errorf("%s should not exist", missingPath)
+	}
+	if _, err := h.readDataset(missingPath); err == nil {
+		t.Errorf("reading %s should fail", missingPath)
+	}
+
+	messages, err := h.readStrings(computeMessagesPaths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 1 || !strings.HasPrefix(messages[0], "Plan: 'Unsteady Multiple 2D Areas'") {
+		t.Errorf("got compute messages %q", messages)
+	}
+}
@@ -0,0 +1,170 @@
+// Functions used to read HDF5 files through GDAL's multidimensional API.
+// The HDF5 raster driver used in hdf.go only lists datasets of two or more dimensions and only reads numeric datasets.
+
+package tools
+
+/*
+#cgo pkg-config: gdal
+#include <stdlib.h>
+#include <string.h>
+#include "gdal.h"
+#include "cpl_conv.h"
+#include "cpl_string.h"
+
+// Append the full names of the arrays of a group and of its subgroups
+static char **listGroupArrays(GDALGroupH group, char **names) {
+	const char *groupName = GDALGroupGetFullName(group);
+	// the full name of the root group is '/'
+	const char *prefix = (groupName == NULL || strcmp(groupName, "/") == 0) ? "" : groupName;
+
+	char **arrays = GDALGroupGetMDArrayNames(group, NULL);
+	for (int i = 0; arrays != NULL && arrays[i] != NULL; i++) {
+		names = CSLAddString(names, CPLSPrintf("%s/%s", prefix, arrays[i]));
+	}
+	CSLDestroy(arrays);
+
+	char **groups = GDALGroupGetGroupNames(group, NULL);
+	for (int i = 0; groups != NULL && groups[i] != NULL; i++) {
+		GDALGroupH child = GDALGroupOpenGroup(group, groups[i], NULL);
+		if (child != NULL) {
+			names = listGroupArrays(child, names);
+			GDALGroupRelease(child);
+		}
+	}
+	CSLDestroy(groups);
+	return names;
+}
+
+// List the full names of every array of a file.
+// Returns 0 on success or -1 if the file cannot be opened as a multidimensional dataset.
+static int listHDFArrays(const char *filename, char ***names) {
+	*names = NULL;
+
+	GDALDatasetH ds = GDALOpenEx(filename, GDAL_OF_MULTIDIM_RASTER | GDAL_OF_READONLY, NULL, NULL, NULL);
+	if (ds == NULL) {
+		return -1;
+	}
+	GDALGroupH root = GDALDatasetGetRootGroup(ds);
+	if (root == NULL) {
+		GDALClose(ds);
+		return -1;
+	}
+	*names = listGroupArrays(root, NULL);
+	GDALGroupRelease(root);
+	GDALClose(ds);
+	return 0;
+}
+
+// Read every string of a scalar or multidimensional string dataset.
+// Returns the number of strings read or -1 if the dataset cannot be opened or read as strings.
+static int readHDFStrings(const char *filename, const char *path, char ***values) {
+	*values = NULL;
+
+	GDALDatasetH ds = GDALOpenEx(filename, GDAL_OF_MULTIDIM_RASTER | GDAL_OF_READONLY, NULL, NULL, NULL);
+	if (ds == NULL) {
+		return -1;
+	}
+	GDALGroupH root = GDALDatasetGetRootGroup(ds);
+	if (root == NULL) {
+		GDALClose(ds);
+		return -1;
+	}
+	GDALMDArrayH array = GDALGroupOpenMDArrayFromFullname(root, path, NULL);
+	GDALGroupRelease(root);
+	if (array == NULL) {
+		GDALClose(ds);
+		return -1;
+	}
+
+	// scalar datasets have no dimensions and hold a single string
+	size_t nDims = 0;
+	GDALDimensionH *dims = GDALMDArrayGetDimensions(array, &nDims);
+	GUInt64 *start = calloc(nDims + 1, sizeof(GUInt64));
+	size_t *count = calloc(nDims + 1, sizeof(size_t));
+	size_t n = 1;
+	for (size_t i = 0; i < nDims; i++) {
+		count[i] = (size_t)GDALDimensionGetSize(dims[i]);
+		n *= count[i];
+	}
+	GDALReleaseDimensions(dims, nDims);
+
+	char **strings = calloc(n + 1, sizeof(char *));
+	GDALExtendedDataTypeH stringType = GDALExtendedDataTypeCreateString(0);
+	int ok = GDALMDArrayRead(array, start, count, NULL, NULL, stringType, strings, strings, (n + 1) * sizeof(char *));
+	GDALExtendedDataTypeRelease(stringType);
+	GDALMDArrayRelease(array);
+	GDALClose(ds);
+	free(start);
+	free(count);
+
+	if (!ok) {
+		for (size_t i = 0; i < n; i++) {
+			CPLFree(strings[i]);
+		}
+		free(strings);
+		return -1;
+	}
+	*values = strings;
+	return (int)n;
+}
+
+// Free strings read by readHDFStrings, each string is allocated by GDAL
+static void freeHDFStrings(char **values, int n) {
+	for (int i = 0; i < n; i++) {
+		CPLFree(values[i]);
+	}
+	free(values);
+}
+*/
+import "C"
+
+import (
+	"strings"
+	"unsafe"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// datasetPaths lists the path of every dataset of the HDF5 file whatever its number of dimensions,
+// e.g. 'Geometry/2D Flow Areas/Perimeter 1/Cells Minimum Elevation'
+func (h hdfFile) datasetPaths() ([]string, error) {
+	paths := []string{}
+
+	cName := C.CString(h.name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cNames **C.char
+	if C.listHDFArrays(cName, &cNames) != 0 {
+		return paths, errors.Errorf("could not list the datasets of %s", h.name)
+	}
+	defer C.CSLDestroy(cNames)
+
+	for _, name := range unsafe.Slice(cNames, int(C.CSLCount(cNames))) {
+		paths = append(paths, strings.Trim(C.GoString(name), "/"))
+	}
+	return paths, nil
+}
+
+// readStrings reads every string of an HDF5 string dataset e.g. 'Results/Summary/Compute Messages (text)'
+func (h hdfFile) readStrings(path string) ([]string, error) {
+	values := []string{}
+
+	cName := C.CString(h.name)
+	defer C.free(unsafe.Pointer(cName))
+	cPath := C.CString("/" + strings.Trim(path, "/"))
+	defer C.free(unsafe.Pointer(cPath))
+
+	var cValues **C.char
+	n := int(C.readHDFStrings(cName, cPath, &cValues))
+	if n < 0 {
+		return values, errors.Errorf("dataset %s does not exist or is not a string dataset", path)
+	}
+	defer C.freeHDFStrings(cValues, C.int(n))
+
+	for _, v := range unsafe.Slice(cValues, n) {
+		if v != nil {
+			values = append(values, C.GoString(v))
+		}
+	}
+	return values, nil
+}
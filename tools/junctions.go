@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Store HEC-RAS Reach Junctions
+type Junction struct {
+	Description     string          `json:"Description"`
+	UpReaches       []junctionReach `json:"Upstream Reaches"`
+	DnReaches       []junctionReach `json:"Downstream Reaches"`
+	ComputationMode string          `json:"Computation Mode"` // either Energy or Momentum
+}
+
+// Store reaches connected to a Junction
+type junctionReach struct {
+	River  string
+	Reach  string
+	Length float64 // length across the junction
+	Angle  float64
+}
+
+// Get river and reach connected to a junction e.g. 'Up River,Reach=Butte Cr.       ,Upper Reach     '
+func getJunctionReach(line string) junctionReach {
+	riverReach := strings.Split(rightofEquals(line), ",")
+	jr := junctionReach{River: strings.TrimSpace(riverReach[0])}
+	if len(riverReach) > 1 {
+		jr.Reach = strings.TrimSpace(riverReach[1])
+	}
+	return jr
+}
+
+// Name used for a reach in geospatial features e.g. 'Butte Cr., Upper Reach'
+func (jr junctionReach) riverReachName() string {
+	return fmt.Sprintf("%s, %s", jr.River, jr.Reach)
+}
+
+// Extract data from Junctions
+func getJunctionData(rm *RasModel, fn string, i int) (string, Junction, error) {
+	var name string
+	junction := Junction{ComputationMode: "Energy"} // HEC-RAS computes junctions with energy unless momentum is selected
+	// lengths and angles apply to the last reach listed
+	var lastReach *junctionReach
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return name, junction, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	jSc := bufio.NewScanner(f)
+
+	ji := 0
+	for jSc.Scan() {
+		ji++
+		if ji == i {
+			name = rightofEquals(jSc.Text())
+		} else if ji > i {
+			line := jSc.Text()
+			switch {
+
+			case strings.HasPrefix(line, "Junct Desc="):
+				junction.Description = strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0])
+
+			case strings.HasPrefix(line, "Up River,Reach="):
+				junction.UpReaches = append(junction.UpReaches, getJunctionReach(line))
+				lastReach = &junction.UpReaches[len(junction.UpReaches)-1]
+
+			case strings.HasPrefix(line, "Dn River,Reach="):
+				junction.DnReaches = append(junction.DnReaches, getJunctionReach(line))
+				lastReach = &junction.DnReaches[len(junction.DnReaches)-1]
+
+			case strings.HasPrefix(line, "Junc L&A=") && lastReach != nil:
+				// e.g. 'Junc L&A=15,0' (length, angle)
+				lineData := strings.Split(rightofEquals(line), ",")
+				length, err := stringtoFloat(lineData[0])
+				if err != nil {
+					return name, junction, errors.Wrap(err, 0)
+				}
+				lastReach.Length = length
+				if len(lineData) > 1 {
+					angle, err := stringtoFloat(lineData[1])
+					if err != nil {
+						return name, junction, errors.Wrap(err, 0)
+					}
+					lastReach.Angle = angle
+				}
+
+			case strings.HasPrefix(line, "Junct Use Energy="):
+				if strings.TrimSpace(rightofEquals(line)) == "0" {
+					junction.ComputationMode = "Momentum"
+				}
+
+			case strings.HasPrefix(line, "Junct Name="), strings.HasPrefix(line, "River Reach="),
+				strings.HasPrefix(line, "Storage Area="), strings.HasPrefix(line, "Connection="):
+				// guard to make sure a new element doesn't overwrite previous values
+				// return with whatever data is available
+				return name, junction, nil
+			}
+		}
+	}
+	return name, junction, nil
+}
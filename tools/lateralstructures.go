@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Map of HEC-RAS Lateral Weir Pos index to the position of the weir along the reach
+var lateralWeirPositions map[string]string = map[string]string{
+	"0": "Left Overbank",
+	"1": "Right Overbank",
+	"2": "Left Overbank in Channel",
+	"3": "Right Overbank in Channel"}
+
+// Store HEC-RAS 1D Lateral Structures
+type LateralStructure struct {
+	River         string      `json:"River Name"`
+	Reach         string      `json:"Reach Name"`
+	Station       float64     `json:"Station"`
+	Name          string      `json:"Name"`
+	Description   string      `json:"Description"`
+	Position      string      `json:"Position"`
+	Distance      float64     `json:"Distance"` // from the upstream cross section
+	WeirWidth     float64     `json:"Weir Width"`
+	WeirElev      maxMinPairs `json:"Weir Elevations"`
+	TailWater     string      `json:"Tail Water"` // SA/2D Area or 'River, Reach, RS' connected to the downstream side of the weir
+	NumGates      int         `json:"Num Gates"`
+	Gates         []gates
+	NumConduits   int        `json:"Num Culvert Conduits"`
+	Conduits      []conduits `json:"Culvert Conduits"`
+	HasCenterline bool       `json:"Has Centerline"`
+}
+
+// Get the tail water connection of a lateral structure
+// e.g. 'Lateral Weir End=River 1,Reach 1,1500,,' or 'Lateral Weir End=,,,,Perimeter 1'
+func lateralWeirTailWater(line string) string {
+	lineData := strings.Split(rightofEquals(line), ",")
+	if len(lineData) >= 3 && strings.TrimSpace(lineData[0]) != "" {
+		return fmt.Sprintf("%s, %s, %s", strings.TrimSpace(lineData[0]), strings.TrimSpace(lineData[1]), strings.TrimSpace(lineData[2]))
+	}
+	for _, val := range lineData {
+		if strings.TrimSpace(val) != "" {
+			return strings.TrimSpace(val)
+		}
+	}
+	return ""
+}
+
+// Extract data from Lateral Structures
+func getLateralStructureData(rm *RasModel, fn string, i int, riverReach string) (string, LateralStructure, error) {
+	var name string
+	lateral := LateralStructure{}
+
+	riverReachData := strings.Split(riverReach, ",")
+	lateral.River = strings.TrimSpace(riverReachData[0])
+	if len(riverReachData) > 1 {
+		lateral.Reach = strings.TrimSpace(riverReachData[1])
+	}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return name, lateral, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	lSc := bufio.NewScanner(f)
+
+	li := 0
+	for lSc.Scan() {
+		li++
+		if li == i {
+			lineData := strings.Split(rightofEquals(lSc.Text()), ",")
+			station, err := parseFloat(strings.TrimSpace(lineData[1]), 64)
+			if err != nil {
+				return name, lateral, errors.Wrap(err, 0)
+			}
+			lateral.Station = station
+			name = fmt.Sprintf("%s, %s, %s", lateral.River, lateral.Reach, strings.TrimSpace(lineData[1]))
+		} else if li > i {
+			line := lSc.Text()
+			switch {
+			case strings.HasPrefix(line, "BEGIN DESCRIPTION"):
+				description, _, err := getDescription(lSc, 0, "END DESCRIPTION:")
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.Description += description
+
+			case strings.HasPrefix(line, "Node Name="):
+				lateral.Name = rightofEquals(line)
+
+			case strings.HasPrefix(line, "Lateral Weir Pos="):
+				lateral.Position = lateralWeirPositions[strings.TrimSpace(rightofEquals(line))]
+
+			case strings.HasPrefix(line, "Lateral Weir Distance="):
+				distance, err := stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.Distance = distance
+
+			case strings.HasPrefix(line, "Lateral Weir WD="):
+				weirWidth, err := stringtoFloat(rightofEquals(line))
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.WeirWidth = weirWidth
+
+			case strings.HasPrefix(line, "Lateral Weir SE="):
+				nElev, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				nLines := numberofLines(nElev*2, 80, 8)
+
+				elev, _, err := getMaxMinElev(lSc, 0, nLines, 0, 80, 8, 2)
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.WeirElev = elev
+
+			case strings.HasPrefix(line, "Lateral Weir End="):
+				lateral.TailWater = lateralWeirTailWater(line)
+
+			case strings.HasPrefix(line, "LW Gate Name Wd,H,"):
+				lSc.Scan()
+				gate, err := getGates(lSc.Text())
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.Gates = append(lateral.Gates, gate)
+				lateral.NumGates++
+
+			case strings.HasPrefix(line, "LW Culv="):
+				conduit, err := getConduits(line, false)
+				if err != nil {
+					return name, lateral, errors.Wrap(err, 0)
+				}
+				lateral.Conduits = append(lateral.Conduits, conduit)
+				lateral.NumConduits++
+
+			case strings.HasPrefix(line, "Lateral Weir Centerline="):
+				lateral.HasCenterline = true
+
+			case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+				return name, lateral, nil
+
+			case strings.HasPrefix(line, "River Reach="), strings.HasPrefix(line, "Storage Area="):
+				return name, lateral, nil
+			}
+		}
+	}
+	return name, lateral, nil
+}
@@ -3,8 +3,10 @@ package tools
 import (
 	"bufio"
 	"fmt"
+	"log"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 
@@ -24,6 +26,9 @@ type fileExtMatchers struct {
 	SteadyRun   *regexp.Regexp
 	UnsteadyRun *regexp.Regexp
 	AllFlowRun  *regexp.Regexp
+	BCOutput    *regexp.Regexp
+	PlanHDF     *regexp.Regexp
+	ComputeLog  *regexp.Regexp
 	Projection  *regexp.Regexp
 }
 
@@ -38,15 +43,17 @@ var RasRE fileExtMatchers = fileExtMatchers{ // Maybe these ones are better? nee
 	SteadyRun:   regexp.MustCompile(".r[0-9][0-9]"),     // `^\.r(0[1-9]|[1-9][0-9])$`
 	UnsteadyRun: regexp.MustCompile(".x[0-9][0-9]"),     // `^\.x(0[1-9]|[1-9][0-9])$`
 	AllFlowRun:  regexp.MustCompile(".[rx][0-9][0-9]"),  // `^\.[rx](0[1-9]|[1-9][0-9])$`
+	BCOutput:    regexp.MustCompile(`^\.bco[0-9][0-9]$`),
+	PlanHDF:     regexp.MustCompile(`\.p[0-9][0-9]\.hdf$`),                          // matched against the file name
+	ComputeLog:  regexp.MustCompile(`\.p[0-9][0-9]\.(computeMsgs|comp_msgs)\.txt$`), // matched against the file name
 	Projection:  regexp.MustCompile(".pr[oj]"),
 }
 
 // holder of multiple wait groups to help process files concurrency
 type rasWaitGroup struct {
-	Geom       sync.WaitGroup
-	Plan       sync.WaitGroup
-	Flow       sync.WaitGroup
-	Projection sync.WaitGroup
+	Geom sync.WaitGroup
+	Plan sync.WaitGroup
+	Flow sync.WaitGroup
 }
 
 // Model is a general type should contain all necessary data for a model of any type.
@@ -115,6 +122,7 @@ type RasModel struct {
 	FileStore      filestore.FileStore
 	ModelDirectory string
 	FileList       []string
+	DirectoryFiles []string // every file in the model directory and its subdirectories
 	Metadata       ProjectMetadata
 }
 
@@ -192,7 +200,36 @@ func (rm *RasModel) Index() Model {
 		mod.Files.InputFiles.ForcingFiles.Data[file] = f
 	}
 
-	// Need to add output and SupplementalFiles files...
+	// paths are compared without a leading '/' since S3 keys do not start with one
+	inputFiles := []string{}
+	for _, paths := range [][]string{{rm.Metadata.ProjFilePath}, mod.Files.InputFiles.ControlFiles.Paths, mod.Files.InputFiles.GeometryFiles.Paths, mod.Files.InputFiles.ForcingFiles.Paths} {
+		for _, p := range paths {
+			inputFiles = append(inputFiles, strings.TrimPrefix(p, "/"))
+		}
+	}
+
+	// Every other file is an output, run or log file. Anything else (.rasmap, .dss, terrain, shapefiles, geometry hdf ...) is supplemental
+	for _, fp := range rm.DirectoryFiles {
+		if stringInSlice(strings.TrimPrefix(fp, "/"), inputFiles) {
+			continue
+		}
+
+		name, ext := filepath.Base(fp), filepath.Ext(fp)
+		switch {
+		case RasRE.ComputeLog.MatchString(name) || RasRE.BCOutput.MatchString(ext):
+			mod.Files.OutputFiles.RunLogs = append(mod.Files.OutputFiles.RunLogs, fp)
+
+		case RasRE.AllFlowRun.MatchString(ext):
+			mod.Files.OutputFiles.RunFiles = append(mod.Files.OutputFiles.RunFiles, fp)
+
+		case RasRE.Output.MatchString(ext) || RasRE.PlanHDF.MatchString(name):
+			mod.Files.OutputFiles.Paths = append(mod.Files.OutputFiles.Paths, fp)
+
+		default:
+			mod.Files.SupplementalFiles.Paths = append(mod.Files.SupplementalFiles.Paths, fp)
+		}
+	}
+
 	return mod
 }
 
@@ -223,7 +260,8 @@ func (rm *RasModel) IsGeospatial() bool {
 }
 
 // GeospatialData ...
-func (rm *RasModel) GeospatialData(destinationCRS int) (GeoData, error) {
+// destinationCRS can be an EPSG code e.g. 'EPSG:4326', a WKT or PROJ string, or 'none' to keep the model projection
+func (rm *RasModel) GeospatialData(destinationCRS string) (GeoData, error) {
 	gd := GeoData{}
 	if rm.IsGeospatial() {
 		modelUnits := rm.Metadata.ProjFileContents.Units
@@ -234,11 +272,10 @@ func (rm *RasModel) GeospatialData(destinationCRS int) (GeoData, error) {
 			return gd, errors.Wrap(err, 0)
 		}
 
-		gd.Features = make(map[string]Features)
-		gd.Georeference = destinationCRS
+		gd = NewGeoData(sourceCRS, destinationCRS)
 
 		for _, g := range rm.Metadata.GeomFiles {
-			if err := GetGeospatialData(&gd, rm.FileStore, g.Path, sourceCRS, destinationCRS); err != nil {
+			if err := GetGeospatialData(&gd, rm.FileStore, g.Path, rm.FileList, sourceCRS, destinationCRS); err != nil {
 				return gd, errors.Wrap(err, 0)
 			}
 		}
@@ -252,12 +289,22 @@ func (rm *RasModel) GeospatialData(destinationCRS int) (GeoData, error) {
 func getModelFiles(rm *RasModel) error {
 	prefix := filepath.Dir(rm.Metadata.ProjFilePath) + "/"
 
-	files, err := rm.FileStore.GetDir(prefix, false)
+	files, err := rm.FileStore.GetDir(prefix, true)
 	if err != nil {
 		return errors.Wrap(err, 0)
 	}
 
 	for _, file := range *files {
+		if file.IsDir {
+			continue
+		}
+		rm.DirectoryFiles = append(rm.DirectoryFiles, filepath.Join(file.Path, file.Name))
+
+		// files in subdirectories (terrain, shapefiles, ...) are only indexed
+		if strings.Trim(file.Path, "/") != strings.Trim(filepath.Dir(rm.Metadata.ProjFilePath), "/") {
+			continue
+		}
+
 		// get only files that share the same base name or .prj files for projection
 		// rational behind .prj file is that there can be a shp file in the same level of Hec-RAS
 		// providing potential projection
@@ -269,14 +316,11 @@ func getModelFiles(rm *RasModel) error {
 	return nil
 }
 
-// getProjection Reads a projection file. returns none to allow concurrency
-func getProjection(rm *RasModel, fn string, wg *sync.WaitGroup) {
-
-	defer wg.Done()
-
+// readProjection reads a projection file and returns its contents if they are a valid coordinate reference system
+func readProjection(rm *RasModel, fn string) (string, error) {
 	f, err := rm.FileStore.GetObject(fn)
 	if err != nil {
-		return
+		return "", errors.Wrap(err, 0)
 	}
 	defer f.Close()
 
@@ -286,17 +330,112 @@ func getProjection(rm *RasModel, fn string, wg *sync.WaitGroup) {
 
 	sourceSpRef := gdal.CreateSpatialReference(line)
 	if err := sourceSpRef.Validate(); err != nil {
-		fmt.Println(fmt.Sprintf("%s is not a valid projection file.", fn))
-		return
+		return "", errors.Errorf("%s is not a valid projection file", fn)
+	}
+	return line, nil
+}
+
+// readGeomHDFProjection returns the projection stored in the attributes of a geometry HDF file
+func readGeomHDFProjection(rm *RasModel, fn string) (string, error) {
+	h, err := openHDF(rm.FileStore, fn)
+	if err != nil {
+		return "", errors.Wrap(err, 0)
+	}
+	defer h.Close()
+
+	wkt, ok := h.attribute("", "Projection")
+	if !ok || wkt == "" {
+		return "", errors.Errorf("%s has no projection attribute", fn)
+	}
+
+	sourceSpRef := gdal.CreateSpatialReference(wkt)
+	if err := sourceSpRef.Validate(); err != nil {
+		return "", errors.Errorf("%s has an invalid projection attribute", fn)
+	}
+	return wkt, nil
+}
+
+// getProjection returns the projection of the model and the file it was read from.
+// Sources are checked in order: name.projection, the RAS Mapper projection,
+// geometry HDF attributes and finally any other .prj or .pro file in the model directory
+func getProjection(rm *RasModel, key string, rasMapProjection string) (string, string, error) {
+	projecFile := strings.TrimSuffix(key, ".prj") + ".projection"
+	projectionFiles := []string{projecFile}
+	if rasMapProjection != "" {
+		projectionFiles = append(projectionFiles, rasMapProjection)
+	}
+
+	candidates := []string{}
+	for _, fp := range projectionFiles {
+		if stringInSlice(fp, rm.DirectoryFiles) {
+			candidates = append(candidates, fp)
+		}
 	}
-	if rm.Metadata.Projection != "" {
-		fmt.Println("Projection already exist for the model.")
-		return
+
+	geomHDFFiles := []string{}
+	for _, fp := range rm.FileList {
+		if RasRE.Geom.MatchString(filepath.Ext(fp)) && stringInSlice(fp+".hdf", rm.DirectoryFiles) {
+			geomHDFFiles = append(geomHDFFiles, fp+".hdf")
+		}
 	}
+	sort.Strings(geomHDFFiles)
+	candidates = append(candidates, geomHDFFiles...)
 
-	rm.Metadata.Projection = line
+	otherFiles := []string{}
+	for _, fp := range rm.FileList {
+		if RasRE.Projection.MatchString(filepath.Ext(fp)) && fp != key && !stringInSlice(fp, projectionFiles) {
+			otherFiles = append(otherFiles, fp)
+		}
+	}
+	sort.Strings(otherFiles)
+	candidates = append(candidates, otherFiles...)
+
+	invalid := []string{}
+	for _, fp := range candidates {
+		var projection string
+		var err error
+		if stringInSlice(fp, geomHDFFiles) {
+			projection, err = readGeomHDFProjection(rm, fp)
+		} else {
+			projection, err = readProjection(rm, fp)
+		}
+		if err != nil {
+			invalid = append(invalid, err.Error())
+			continue
+		}
+		return projection, fp, nil
+	}
 
-	return
+	if len(invalid) == 0 {
+		return "", "", errors.New("no projection file found")
+	}
+	return "", "", errors.Errorf("no valid projection found: %s", strings.Join(invalid, "; "))
+}
+
+// ResolveProjection returns the projection of a model and the file it was read from,
+// without reading the plan, geometry and flow files of the model
+func ResolveProjection(key string, fs filestore.FileStore) (string, string, error) {
+	rm := RasModel{ModelDirectory: filepath.Dir(key), FileStore: fs, Type: "RAS"}
+
+	if err := verifyPrjPath(key, &rm); err != nil {
+		return "", "", errors.Wrap(err, 0)
+	}
+
+	if err := getModelFiles(&rm); err != nil {
+		return "", "", errors.Wrap(err, 0)
+	}
+
+	// a RAS Mapper file that cannot be read only removes one of the projection sources
+	rasMapProjection := ""
+	if rmf, rasMapFile, err := readRasMapFile(&rm); err == nil && rasMapFile != "" {
+		rasMapProjection = rasMapPath(rasMapFile, rmf.Projection.Filename)
+	}
+
+	projection, source, err := getProjection(&rm, key, rasMapProjection)
+	if err != nil {
+		return "", "", errors.Wrap(err, 0)
+	}
+	return projection, source, nil
 }
 
 // NewRasModel ...
@@ -320,11 +459,6 @@ func NewRasModel(key string, fs filestore.FileStore) (*RasModel, error) {
 
 	var rasWG rasWaitGroup
 
-	// get projection using name.projection file
-	rasWG.Projection.Add(1)
-	projecFile := strings.TrimSuffix(key, ".prj") + ".projection"
-	go getProjection(&rm, projecFile, &rasWG.Projection)
-
 	for _, fp := range rm.FileList {
 
 		ext := filepath.Ext(fp)
@@ -343,19 +477,24 @@ func NewRasModel(key string, fs filestore.FileStore) (*RasModel, error) {
 			rasWG.Flow.Add(1)
 			go getFlowData(&rm, fp, &rasWG.Flow)
 
-		case rm.Metadata.Projection == "" && RasRE.Projection.MatchString(ext):
-			if filepath.Base(key) != filepath.Base(fp) && fp != projecFile {
-				rasWG.Projection.Add(1)
-				go getProjection(&rm, fp, &rasWG.Projection)
-			}
-
 		}
 	}
 
 	rasWG.Plan.Wait()
 	rasWG.Geom.Wait()
 	rasWG.Flow.Wait()
-	rasWG.Projection.Wait()
+
+	err = getRasMapData(&rm)
+	if err != nil {
+		log.Println("RAS Mapper|", err)
+	}
+	setTwoDAreaLandCover(&rm)
+
+	projection, source, err := getProjection(&rm, key, rm.Metadata.RasMapContents.Projection)
+	if err != nil {
+		log.Println("Projection|", err)
+	}
+	rm.Metadata.Projection, rm.Metadata.ProjectionSource = projection, source
 
 	for _, p := range rm.Metadata.PlanFiles {
 		version := p.ProgramVersion
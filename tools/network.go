@@ -0,0 +1,159 @@
+// Structs and functions used to build the river network of HEC-RAS geometry files.
+
+package tools
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Network directed graph of the reaches of a geometry file
+type Network struct {
+	Reaches         map[string]*NetworkReach `json:"reaches"`
+	DanglingReaches []string                 `json:"dangling_reaches"` // reaches not connected to any junction
+	UnknownReaches  []string                 `json:"unknown_reaches"`  // reaches referenced by junctions but not found in the geometry file
+}
+
+// NetworkReach node of the river network
+type NetworkReach struct {
+	River              string   `json:"river"`
+	Reach              string   `json:"reach"`
+	UpstreamJunction   string   `json:"upstream_junction,omitempty"`
+	DownstreamJunction string   `json:"downstream_junction,omitempty"`
+	Upstream           []string `json:"upstream"`       // reaches flowing into this reach
+	Downstream         []string `json:"downstream"`     // reaches this reach flows into
+	CrossSections      []string `json:"cross_sections"` // river stations ordered from upstream to downstream
+	OutOfOrderStations []string `json:"out_of_order_stations"`
+	DuplicateStations  []string `json:"duplicate_stations"`
+}
+
+// Order the cross sections of a reach and check that they are stored from upstream to downstream.
+// HEC-RAS requires river stations to decrease in the downstream direction.
+func orderReachStations(nr *NetworkReach, stations []string) error {
+	type xsStation struct {
+		name  string
+		value float64
+	}
+	xsStations := []xsStation{}
+	seen := map[float64]bool{}
+
+	for i, rs := range stations {
+		// interpolated cross sections are marked with a '*'
+		num, err := toNumeric(rs)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+		value, err := parseFloat(num, 64)
+		if err != nil {
+			return errors.Wrap(err, 0)
+		}
+
+		if seen[value] {
+			nr.DuplicateStations = append(nr.DuplicateStations, rs)
+		} else if i > 0 && value > xsStations[len(xsStations)-1].value {
+			nr.OutOfOrderStations = append(nr.OutOfOrderStations, rs)
+		}
+		seen[value] = true
+		xsStations = append(xsStations, xsStation{rs, value})
+	}
+
+	sort.SliceStable(xsStations, func(i, j int) bool { return xsStations[i].value > xsStations[j].value })
+	for _, xs := range xsStations {
+		nr.CrossSections = append(nr.CrossSections, xs.name)
+	}
+	return nil
+}
+
+// Build the river network of a geometry file from its reaches, junctions and cross sections
+func getNetwork(rm *RasModel, g GeomFileContents) (Network, error) {
+	network := Network{Reaches: make(map[string]*NetworkReach), DanglingReaches: []string{}, UnknownReaches: []string{}}
+
+	for _, s := range g.Structures {
+		name := fmt.Sprintf("%s, %s", s.River, s.Reach)
+		network.Reaches[name] = &NetworkReach{River: s.River, Reach: s.Reach, Upstream: []string{}, Downstream: []string{},
+			CrossSections: []string{}, OutOfOrderStations: []string{}, DuplicateStations: []string{}}
+	}
+
+	xsOrder, err := getXSOrder(rm, g.Path)
+	if err != nil {
+		return network, errors.Wrap(err, 0)
+	}
+	reachStations := make(map[string][]string)
+	for _, xs := range xsOrder {
+		name := fmt.Sprintf("%s, %s", xs[0], xs[1])
+		reachStations[name] = append(reachStations[name], xs[2])
+	}
+	for name, stations := range reachStations {
+		if nr, ok := network.Reaches[name]; ok {
+			if err := orderReachStations(nr, stations); err != nil {
+				return network, errors.Wrap(err, 0)
+			}
+		}
+	}
+
+	// reaches upstream of a junction flow into every reach downstream of it
+	for junctName, junction := range g.Junctions {
+		for _, jr := range append(junction.UpReaches, junction.DnReaches...) {
+			if _, ok := network.Reaches[jr.riverReachName()]; !ok && !stringInSlice(jr.riverReachName(), network.UnknownReaches) {
+				network.UnknownReaches = append(network.UnknownReaches, jr.riverReachName())
+			}
+		}
+
+		for _, up := range junction.UpReaches {
+			upReach, ok := network.Reaches[up.riverReachName()]
+			if !ok {
+				continue
+			}
+			upReach.DownstreamJunction = junctName
+			for _, dn := range junction.DnReaches {
+				upReach.Downstream = append(upReach.Downstream, dn.riverReachName())
+			}
+		}
+
+		for _, dn := range junction.DnReaches {
+			dnReach, ok := network.Reaches[dn.riverReachName()]
+			if !ok {
+				continue
+			}
+			dnReach.UpstreamJunction = junctName
+			for _, up := range junction.UpReaches {
+				dnReach.Upstream = append(dnReach.Upstream, up.riverReachName())
+			}
+		}
+	}
+
+	// a model with a single reach does not need junctions
+	if len(network.Reaches) > 1 {
+		for name, nr := range network.Reaches {
+			if nr.UpstreamJunction == "" && nr.DownstreamJunction == "" {
+				network.DanglingReaches = append(network.DanglingReaches, name)
+			}
+		}
+	}
+	sort.Strings(network.DanglingReaches)
+	sort.Strings(network.UnknownReaches)
+
+	return network, nil
+}
+
+// Network builds the river network of every geometry file with 1D reaches
+func (rm *RasModel) Network() (map[string]Network, error) {
+	networks := make(map[string]Network)
+
+	for _, g := range rm.Metadata.GeomFiles {
+		if len(g.Structures) == 0 {
+			continue
+		}
+
+		network, err := getNetwork(rm, g)
+		if err != nil {
+			return networks, errors.Wrap(err, 0)
+		}
+		networks[filepath.Base(g.Path)] = network
+	}
+
+	return networks, nil
+}
@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func TestOrderReachStations(t *testing.T) {
+	nr := NetworkReach{}
+	if err := orderReachStations(&nr, []string{"5.99", "5.875*", "5.9", "5.9"}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"5.99", "5.9", "5.9", "5.875*"}; !reflect.DeepEqual(nr.CrossSections, want) {
+		t.Errorf("got cross sections %v, want %v", nr.CrossSections, want)
+	}
+	if want := []string{"5.9"}; !reflect.DeepEqual(nr.OutOfOrderStations, want) {
+		t.Errorf("got out of order stations %v, want %v", nr.OutOfOrderStations, want)
+	}
+	if want := []string{"5.9"}; !reflect.DeepEqual(nr.DuplicateStations, want) {
+		t.Errorf("got duplicate stations %v, want %v", nr.DuplicateStations, want)
+	}
+}
+
+func TestGetNetwork(t *testing.T) {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm := RasModel{FileStore: fs}
+
+	g := GeomFileContents{
+		Path: "testdata/Network.g01",
+		Structures: []hydraulicStructures{
+			{River: "Butte Cr.", Reach: "Upper Reach"},
+			{River: "Butte Cr.", Reach: "Lower Reach"},
+			{River: "Tributary", Reach: "Trib"},
+			{River: "Spring Cr.", Reach: "Side Channel"},
+		},
+		Junctions: map[string]Junction{
+			"Sutter": {
+				UpReaches: []junctionReach{{River: "Butte Cr.", Reach: "Upper Reach"}, {River: "Tributary", Reach: "Trib"}},
+				DnReaches: []junctionReach{{River: "Butte Cr.", Reach: "Lower Reach"}, {River: "Butte Cr.", Reach: "Missing Reach"}},
+			},
+		},
+	}
+
+	network, err := getNetwork(&rm, g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	upper := network.Reaches["Butte Cr., Upper Reach"]
+	if upper.DownstreamJunction != "Sutter" || !reflect.DeepEqual(upper.Downstream, []string{"Butte Cr., Lower Reach", "Butte Cr., Missing Reach"}) {
+		t.Errorf("got upper reach %+v", upper)
+	}
+	if !reflect.DeepEqual(upper.OutOfOrderStations, []string{"5.9"}) {
+		t.Errorf("got out of order stations %v", upper.OutOfOrderStations)
+	}
+
+	lower := network.Reaches["Butte Cr., Lower Reach"]
+	if lower.UpstreamJunction != "Sutter" || !reflect.DeepEqual(lower.Upstream, []string{"Butte Cr., Upper Reach", "Tributary, Trib"}) {
+		t.Errorf("got lower reach %+v", lower)
+	}
+	if !reflect.DeepEqual(lower.DuplicateStations, []string{"4.2"}) {
+		t.Errorf("got duplicate stations %v", lower.DuplicateStations)
+	}
+
+	if want := []string{"Spring Cr., Side Channel"}; !reflect.DeepEqual(network.DanglingReaches, want) {
+		t.Errorf("got dangling reaches %v, want %v", network.DanglingReaches, want)
+	}
+	if want := []string{"Butte Cr., Missing Reach"}; !reflect.DeepEqual(network.UnknownReaches, want) {
+		t.Errorf("got unknown reaches %v, want %v", network.UnknownReaches, want)
+	}
+}
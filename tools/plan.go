@@ -24,7 +24,33 @@ type PlanFileContents struct {
 	FlowFile        string //`json:"Flow File"` // unsteady or steady both flow files are stored as FlowFile in HEC RAS plan file, replicating the same here
 	FlowRegime      string //`json:"FlowRegime"`
 	Description     string //`json:"Description"`
-	Notes           string
+	SimulationStart string //`json:"Simulation Start"`
+	SimulationEnd   string //`json:"Simulation End"`
+	// Computation and output intervals are kept in HEC-RAS notation e.g. 30SEC, 1MIN, 1HOUR
+	ComputationInterval   string //`json:"Computation Interval"`
+	OutputInterval        string //`json:"Output Interval"`
+	InstantaneousInterval string //`json:"Instantaneous Interval"`
+	MappingInterval       string //`json:"Mapping Interval"`
+	RunHTab               bool   //`json:"Run HTab"`
+	RunUNet               bool   //`json:"Run UNet"`
+	RunPostProcess        bool   //`json:"Run PostProcess"`
+	RunRASMapper          bool   //`json:"Run RASMapper"`
+	FrictionSlopeMethod   string //`json:"Friction Slope Method"`
+	Notes                 string
+}
+
+// Map of HEC-RAS friction slope methods
+var frictionSlopeMethodMapping = map[string]string{
+	"1": "Average Conveyance",
+	"2": "Average Friction Slope",
+	"3": "Geometric Mean Friction Slope",
+	"4": "Harmonic Mean Friction Slope",
+}
+
+// runFlag converts HEC-RAS plan run flags e.g. 'Run HTab= 1 ' to bool
+func runFlag(s string) bool {
+	flag := strings.TrimSpace(s)
+	return flag != "" && flag != "0"
 }
 
 // getPlanData Reads a plan file. returns none to allow concurrency
@@ -94,6 +120,45 @@ func getPlanData(rm *RasModel, fn string, wg *sync.WaitGroup) {
 			case "Flow File":
 				meta.FlowFile = data[1]
 
+			case "Simulation Date":
+				// e.g. 01JAN2000,0000,05JAN2000,2400
+				simDate := strings.Split(data[1], ",")
+				if len(simDate) == 4 {
+					meta.SimulationStart = strings.TrimSpace(simDate[0]) + " " + strings.TrimSpace(simDate[1])
+					meta.SimulationEnd = strings.TrimSpace(simDate[2]) + " " + strings.TrimSpace(simDate[3])
+				}
+
+			case "Computation Interval":
+				meta.ComputationInterval = strings.TrimSpace(data[1])
+
+			case "Output Interval":
+				meta.OutputInterval = strings.TrimSpace(data[1])
+
+			case "Instantaneous Interval":
+				meta.InstantaneousInterval = strings.TrimSpace(data[1])
+
+			case "Mapping Interval":
+				meta.MappingInterval = strings.TrimSpace(data[1])
+
+			case "Run HTab":
+				meta.RunHTab = runFlag(data[1])
+
+			case "Run UNet":
+				meta.RunUNet = runFlag(data[1])
+
+			case "Run PostProcess":
+				meta.RunPostProcess = runFlag(data[1])
+
+			case "Run RASMapper":
+				meta.RunRASMapper = runFlag(data[1])
+
+			case "Friction Slope Method":
+				method := strings.TrimSpace(strings.Split(data[1], ",")[0])
+				if val, ok := frictionSlopeMethodMapping[method]; ok {
+					method = val
+				}
+				meta.FrictionSlopeMethod = method
+
 			}
 
 		} else if beginDescription {
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func TestGetPlanData(t *testing.T) {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm := RasModel{FileStore: fs}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	getPlanData(&rm, "testdata/Muncie.p01", &wg)
+
+	if len(rm.Metadata.PlanFiles) != 1 {
+		t.Fatalf("got %d plan files, want 1", len(rm.Metadata.PlanFiles))
+	}
+	meta := rm.Metadata.PlanFiles[0]
+	if meta.Notes != "" || len(meta.Hash) != 64 {
+		t.Errorf("got notes %q and hash %q", meta.Notes, meta.Hash)
+	}
+	meta.Hash = ""
+
+	want := PlanFileContents{
+		Path:                  "testdata/Muncie.p01",
+		FileExt:               ".p01",
+		PlanTitle:             "Unsteady Run",
+		ShortIdentifier:       "Unsteady",
+		ProgramVersion:        "6.30",
+		GeomFile:              "g01",
+		FlowFile:              "u01",
+		FlowRegime:            "Subcritical Flow",
+		Description:           "Unsteady run of the Muncie model\n",
+		SimulationStart:       "01JAN2020 0000",
+		SimulationEnd:         "05JAN2020 2400",
+		ComputationInterval:   "30SEC",
+		OutputInterval:        "1HOUR",
+		InstantaneousInterval: "15MIN",
+		MappingInterval:       "1HOUR",
+		RunHTab:               true,
+		RunUNet:               true,
+		RunRASMapper:          true,
+		FrictionSlopeMethod:   "Average Friction Slope",
+	}
+	if meta != want {
+		t.Errorf("got plan %+v, want %+v", meta, want)
+	}
+}
+
+func TestRunFlag(t *testing.T) {
+	for s, want := range map[string]bool{" 1 ": true, "-1": true, " 0 ": false, "": false} {
+		if got := runFlag(s); got != want {
+			t.Errorf("runFlag(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
@@ -21,6 +21,8 @@ type ProjectMetadata struct {
 	FlowFiles        []FlowFileContents //`json:"Flow Data"`
 	GeomFiles        []GeomFileContents //`json:"Geometry Data"`
 	Projection       string             //`json:"Projection"`
+	ProjectionSource string             //`json:"Projection Source"`
+	RasMapContents   RasMapContents     //`json:"RAS Mapper Data"`
 	Notes            string             //`json:"Notes"`
 }
 
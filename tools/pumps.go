@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Store HEC-RAS Pump Stations
+type PumpStation struct {
+	Description string       `json:"Description"`
+	FromType    string       `json:"From Type"` // either Reach or Area
+	From        string       `json:"From"`      // SA/2D Area or 'River, Reach, RS'
+	ToType      string       `json:"To Type"`
+	To          string       `json:"To"`
+	NumGroups   int          `json:"Num Pump Groups"`
+	Groups      []pumpGroups `json:"Pump Groups"`
+}
+
+// Store Pump Groups in HEC-RAS Pump Stations
+type pumpGroups struct {
+	Name            string
+	NumPumps        int          `json:"Num Pumps"`
+	PumpCurve       [][2]float64 `json:"Pump Curve"`        // head, flow pairs
+	OnOffElevations [][2]float64 `json:"On Off Elevations"` // on, off elevation of each pump
+}
+
+// Get the element connected to a pump station
+// e.g. 'Pump From Location=River 1,Reach 1,1500,,' or 'Pump To Location=,,,Storage Area 1,'
+func pumpLocation(line string) (string, string) {
+	lineData := strings.Split(rightofEquals(line), ",")
+	if len(lineData) >= 3 && strings.TrimSpace(lineData[0]) != "" {
+		return "Reach", fmt.Sprintf("%s, %s, %s", strings.TrimSpace(lineData[0]), strings.TrimSpace(lineData[1]), strings.TrimSpace(lineData[2]))
+	}
+	for _, val := range lineData {
+		if strings.TrimSpace(val) != "" {
+			return "Area", strings.TrimSpace(val)
+		}
+	}
+	return "", ""
+}
+
+// Extract data from Pump Stations
+func getPumpStationData(rm *RasModel, fn string, i int) (string, PumpStation, error) {
+	var name string
+	var pump PumpStation
+	var group *pumpGroups
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return name, pump, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	pSc := bufio.NewScanner(f)
+
+	pi := 0
+	for pSc.Scan() {
+		pi++
+		if pi == i {
+			lineData := strings.Split(rightofEquals(pSc.Text()), ",")
+			name = strings.TrimSpace(lineData[0])
+		} else if pi > i {
+			line := pSc.Text()
+			switch {
+
+			case strings.HasPrefix(line, "BEGIN DESCRIPTION"):
+				description, _, err := getDescription(pSc, 0, "END DESCRIPTION:")
+				if err != nil {
+					return name, pump, errors.Wrap(err, 0)
+				}
+				pump.Description += description
+
+			case strings.HasPrefix(line, "Pump From Location="):
+				pump.FromType, pump.From = pumpLocation(line)
+
+			case strings.HasPrefix(line, "Pump To Location="):
+				pump.ToType, pump.To = pumpLocation(line)
+
+			case strings.HasPrefix(line, "Pump Group="):
+				// e.g. 'Pump Group=Group #1,2' (name, number of pumps)
+				lineData := strings.Split(rightofEquals(line), ",")
+				pump.Groups = append(pump.Groups, pumpGroups{Name: strings.TrimSpace(lineData[0])})
+				pump.NumGroups++
+				group = &pump.Groups[len(pump.Groups)-1]
+				if len(lineData) > 1 && strings.TrimSpace(lineData[1]) != "" {
+					numPumps, err := strconv.Atoi(strings.TrimSpace(lineData[1]))
+					if err != nil {
+						return name, pump, errors.Wrap(err, 0)
+					}
+					group.NumPumps = numPumps
+				}
+
+			case strings.HasPrefix(line, "Pump Group Curve=") && group != nil:
+				pairs, err := getDataPairsfromTextBlock("Pump Group Curve=", pSc, 80, 8)
+				if err != nil {
+					return name, pump, errors.Wrap(err, 0)
+				}
+				group.PumpCurve = pairs
+
+			case strings.HasPrefix(line, "Pump Group On Off=") && group != nil:
+				lineData := strings.Split(rightofEquals(line), ",")
+				if len(lineData) < 2 {
+					continue
+				}
+				onElev, err := stringtoFloat(lineData[0])
+				if err != nil {
+					return name, pump, errors.Wrap(err, 0)
+				}
+				offElev, err := stringtoFloat(lineData[1])
+				if err != nil {
+					return name, pump, errors.Wrap(err, 0)
+				}
+				group.OnOffElevations = append(group.OnOffElevations, [2]float64{onElev, offElev})
+
+			case strings.HasPrefix(line, "Pump Station="), strings.HasPrefix(line, "Storage Area="),
+				strings.HasPrefix(line, "Connection="), strings.HasPrefix(line, "River Reach="):
+				// guard to make sure a new element doesn't overwrite previous values
+				// return with whatever data is available
+				return name, pump, nil
+			}
+		}
+	}
+	return name, pump, nil
+}
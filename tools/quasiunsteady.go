@@ -0,0 +1,235 @@
+// Structs and functions used to parse quasi-unsteady flow files.
+
+package tools
+
+import (
+	"bufio"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/USACE/filestore"
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// These prefixes are used to determine the beginning and end of HEC-RAS elements
+var quasiUnsteadyElementsPrefix = [...]string{
+	"Flow Title",
+	"Program Version",
+	"Boundary Location",
+	"Temperature Series",
+}
+
+// Quasi-Unsteady Data
+type QuasiUnsteadyData struct {
+	FlowTitle      string
+	ProgramVersion string
+	// There can be many boundary conditions for the same reach
+	BoundaryConditions map[string][]BoundaryCondition
+	Temperature        *QuasiUnsteadySeries `json:",omitempty"`
+}
+
+// Quasi-Unsteady Series.
+// Can be Flow, Lateral Flow, Uniform Lateral Flow, Stage, or Temperature Series.
+type QuasiUnsteadySeries struct {
+	EndRS              string              `json:"flow_distribution_last_RS,omitempty"` // flow will be distributed from RS to EndRS. Valid for Uniform Lateral Flow Series
+	Values             []QuasiUnsteadyStep `json:"values,omitempty"`
+	UseDSS             bool                `json:"use_dss"`
+	DSSFile            string              `json:"dss_file,omitempty"`
+	DSSPath            string              `json:"dss_path,omitempty"`
+	UseFixedStart      bool                `json:"fixed_start"`
+	FixedStartDateTime *DateTime           `json:"fixed_start_date_time,omitempty"` // pointer to have zero value, so that omitempty can work
+}
+
+// Quasi-Unsteady Series Step.
+// Value is held constant for Duration hours and computed every ComputationIncrement hours.
+type QuasiUnsteadyStep struct {
+	Duration             float64 `json:"duration"`
+	ComputationIncrement float64 `json:"computation_increment,omitempty"` // not available for Temperature Series
+	Value                float64 `json:"value"`
+}
+
+// Get Quasi-Unsteady Series Data.
+// nFields is 3 for flow and stage series (duration, computation increment, value) and 2 for temperature series (duration, value).
+// Returns at EOF or if new Quasi-Unsteady element is encountered
+func getQuasiUnsteadySeries(sc *bufio.Scanner, nFields int, flowEndRS string) (qs QuasiUnsteadySeries, skipScan bool, err error) {
+
+	if flowEndRS != "" {
+		qs.EndRS = flowEndRS
+	}
+
+	numSteps, innerErr := strconv.Atoi(strings.TrimSpace(rightofEquals(sc.Text())))
+	if innerErr != nil {
+		return qs, false, innerErr
+	}
+	if numSteps != 0 {
+		series, innerErr := seriesFromTextBlock(sc, numSteps*nFields, 80, 8)
+		if innerErr != nil {
+			return qs, false, innerErr
+		}
+		for s := 0; s < len(series); s += nFields {
+			step := QuasiUnsteadyStep{Duration: series[s], Value: series[s+nFields-1]}
+			if nFields == 3 {
+				step.ComputationIncrement = series[s+1]
+			}
+			qs.Values = append(qs.Values, step)
+		}
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		if stringInSlice(loe, quasiUnsteadyElementsPrefix[:]) {
+			return qs, true, nil
+		}
+
+		switch loe {
+		case "Use DSS":
+			if rightofEquals(line) == "True" {
+				qs.UseDSS = true
+			}
+		case "DSS File":
+			qs.DSSFile = strings.TrimSpace(rightofEquals(line))
+		case "DSS Path":
+			qs.DSSPath = strings.TrimSpace(rightofEquals(line))
+		case "Use Fixed Start Time":
+			if strings.TrimSpace(rightofEquals(line)) == "True" {
+				qs.UseFixedStart = true
+			}
+		case "Fixed Start Date/Time":
+			fsdt := strings.Split(rightofEquals(line), ",")
+			if len(fsdt[0]) > 0 {
+				qs.FixedStartDateTime = &DateTime{}
+				qs.FixedStartDateTime.Date = fsdt[0]
+				qs.FixedStartDateTime.Hours = fsdt[1]
+			}
+		}
+	}
+	return
+}
+
+// Get Quasi-Unsteady Boundary Condition's data.
+// Advances the given scanner.
+// Returns if new RAS element is encountered or all necessary data is obtained.
+func getQuasiUnsteadyBoundaryCondition(sc *bufio.Scanner) (parentType string, parent string, bc BoundaryCondition, skipScan bool, err error) {
+
+	// Quasi-Unsteady Boundary Locations share the same header as Unsteady Boundary Locations
+	parentType, parent, flowEndRS, bc, err := parseUnsteadyBCHeader(sc.Text())
+	if err != nil {
+		return
+	}
+
+	// Get type and data of boundary condition
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+		if stringInSlice(loe, quasiUnsteadyElementsPrefix[:]) {
+			if bc.Type == "" {
+				bc.Type = "Unknown Type"
+			}
+			skipScan = true // a new HEC RAS element has been encountered, skip next scan and return
+			return
+		}
+
+		switch loe {
+		case "Friction Slope":
+			bc.Type = "Normal Depth"
+			slope, innerErr := parseFloat(strings.TrimSpace(strings.Split(rightofEquals(line), ",")[0]), 64)
+			if innerErr != nil {
+				err = errors.Wrap(innerErr, 0)
+				return
+			}
+			bc.Data = map[string]float64{"Friction Slope": slope}
+			return
+
+		case "Rating Curve":
+			series, innerErr := getDataPairsfromTextBlock(loe, sc, 80, 8)
+			if innerErr != nil {
+				err = innerErr
+				return
+			}
+			bc.Data = RatingCurve{Values: series}
+			bc.Type = loe
+			return
+
+		case "Flow Series", "Lateral Flow Series", "Uniform Lateral Flow Series", "Stage Series":
+			bc.Type = loe
+			qs, ss, innerErr := getQuasiUnsteadySeries(sc, 3, flowEndRS)
+			skipScan = ss
+			if innerErr != nil {
+				err = innerErr
+				return
+			}
+			bc.Data = qs
+			return
+		}
+	}
+
+	return
+}
+
+// Get Forcing Data from quasi-unsteady flow file.
+func getQuasiUnsteadyData(fd *ForcingData, fs filestore.FileStore, flowFilePath string, mu *sync.Mutex) error {
+	flowFileName := filepath.Base(flowFilePath)
+	qd := QuasiUnsteadyData{
+		BoundaryConditions: make(map[string][]BoundaryCondition),
+	}
+
+	file, err := fs.GetObject(flowFilePath)
+	if err != nil {
+		return errors.Wrap(err, 0)
+	}
+	defer file.Close()
+
+	sc := bufio.NewScanner(file)
+	if err := sc.Err(); err != nil {
+		return err
+	}
+
+	eof := !sc.Scan()
+	for !eof {
+		skipScan := false
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		switch loe {
+		case "Flow Title":
+			qd.FlowTitle = strings.TrimSpace(rightofEquals(line))
+		case "Program Version":
+			qd.ProgramVersion = strings.TrimSpace(rightofEquals(line))
+		case "Boundary Location":
+			parentType, parent, bc, ss, err := getQuasiUnsteadyBoundaryCondition(sc)
+			skipScan = ss
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			// Quasi-Unsteady flow is only computed on 1D reaches
+			if parentType == "Reach" {
+				qd.BoundaryConditions[parent] = append(qd.BoundaryConditions[parent], bc)
+			}
+		case "Temperature Series":
+			ts, ss, err := getQuasiUnsteadySeries(sc, 2, "")
+			skipScan = ss
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			qd.Temperature = &ts
+		}
+
+		// if a new RAS element is encountered during the functions call, scanning again will skip that element, therefore skip scan
+		if !skipScan {
+			eof = !sc.Scan()
+			if err := sc.Err(); err != nil {
+				return err
+			}
+		}
+	}
+
+	mu.Lock()
+	fd.QuasiUnsteady[flowFileName] = qd
+	mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package tools
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func readTestQuasiUnsteadyData(t *testing.T, fn string) (QuasiUnsteadyData, error) {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := ForcingData{QuasiUnsteady: make(map[string]QuasiUnsteadyData)}
+	err = getQuasiUnsteadyData(&fd, fs, fn, &sync.Mutex{})
+	return fd.QuasiUnsteady[filepath.Base(fn)], err
+}
+
+func TestGetQuasiUnsteadyData(t *testing.T) {
+	qd, err := readTestQuasiUnsteadyData(t, "testdata/Sediment.q01")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if qd.FlowTitle != "Sediment Transport" || qd.ProgramVersion != "6.30" {
+		t.Errorf("got flow title %q and program version %q", qd.FlowTitle, qd.ProgramVersion)
+	}
+
+	want := []BoundaryCondition{
+		{
+			RS:   "15696.24",
+			Type: "Flow Series",
+			Data: QuasiUnsteadySeries{
+				Values:             []QuasiUnsteadyStep{{24, 1, 1000}, {48, 2, 1500}},
+				UseFixedStart:      true,
+				FixedStartDateTime: &DateTime{Date: "01JAN2020", Hours: "0000"},
+			},
+		},
+		{
+			RS:   "5.99",
+			Type: "Uniform Lateral Flow Series",
+			Data: QuasiUnsteadySeries{
+				EndRS:   "5.5",
+				Values:  []QuasiUnsteadyStep{{24, 1, 50}},
+				UseDSS:  true,
+				DSSFile: "Sediment.dss",
+				DSSPath: "/WHITE/MUNCIE/FLOW//1HOUR/OBS/",
+			},
+		},
+		{
+			RS:   "0.2",
+			Type: "Normal Depth",
+			Data: map[string]float64{"Friction Slope": 0.001},
+		},
+	}
+	if got := qd.BoundaryConditions["White - Muncie"]; !reflect.DeepEqual(got, want) {
+		t.Errorf("got boundary conditions %+v, want %+v", got, want)
+	}
+
+	wantTemperature := &QuasiUnsteadySeries{Values: []QuasiUnsteadyStep{{Duration: 24, Value: 60}, {Duration: 48, Value: 65}}}
+	if !reflect.DeepEqual(qd.Temperature, wantTemperature) {
+		t.Errorf("got temperature series %+v, want %+v", qd.Temperature, wantTemperature)
+	}
+}
+
+func TestGetQuasiUnsteadyDataInvalidFrictionSlope(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "Invalid.q01")
+	contents := "Flow Title=Invalid\r\n" +
+		"Boundary Location=White           ,Muncie          ,0.2     ,        ,                ,                ,                ,                \r\n" +
+		"Friction Slope=0.00l,0\r\n"
+	if err := os.WriteFile(fn, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := readTestQuasiUnsteadyData(t, fn); err == nil {
+		t.Error("expected an error for an invalid friction slope")
+	}
+}
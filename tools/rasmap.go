@@ -0,0 +1,218 @@
+// Structs and functions used to read HEC-RAS Mapper (.rasmap) files.
+
+package tools
+
+import (
+	"encoding/xml"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// RasMapContents layers and settings listed in the RAS Mapper file
+type RasMapContents struct {
+	Path               string
+	Projection         string         `json:"Projection"` // RAS Mapper projection file
+	Terrains           []TerrainLayer `json:"Terrains"`
+	LandCoverLayers    []RasMapLayer  `json:"Land Cover Layers"`
+	InfiltrationLayers []RasMapLayer  `json:"Infiltration Layers"`
+	Results            []ResultsLayer `json:"Results"`
+}
+
+// RasMapLayer layer listed in the RAS Mapper file
+type RasMapLayer struct {
+	Name     string
+	Filename string
+	Geometry string `json:"Geometry,omitempty"` // geometry HDF file the layer is associated with
+}
+
+// TerrainLayer terrain HDF and the source rasters it was built from
+type TerrainLayer struct {
+	Name        string
+	Filename    string
+	SourceFiles []string `json:"Source Files"`
+}
+
+// ResultsLayer plan results and the maps configured for it
+type ResultsLayer struct {
+	Name     string
+	Filename string
+	Maps     []ResultsMap
+}
+
+// ResultsMap result map configured in RAS Mapper
+type ResultsMap struct {
+	Name           string
+	MapType        string `json:"Map Type"`
+	Profile        string
+	OutputMode     string `json:"Output Mode"` // e.g. Dynamic, Stored Current Terrain
+	StoredFilename string `json:"Stored Filename,omitempty"`
+}
+
+// rasMapLayer xml layer, layers can be nested e.g. result maps within a results layer
+type rasMapLayer struct {
+	Name          string        `xml:"Name,attr"`
+	Type          string        `xml:"Type,attr"`
+	Filename      string        `xml:"Filename,attr"`
+	Layers        []rasMapLayer `xml:"Layer"`
+	MapParameters struct {
+		MapType        string `xml:"MapType,attr"`
+		OutputMode     string `xml:"OutputMode,attr"`
+		StoredFilename string `xml:"StoredFilename,attr"`
+		ProfileName    string `xml:"ProfileName,attr"`
+	} `xml:"MapParameters"`
+}
+
+// rasMapXML xml root of the RAS Mapper file
+type rasMapXML struct {
+	XMLName    xml.Name `xml:"RASMapper"`
+	Projection struct {
+		Filename string `xml:"Filename,attr"`
+	} `xml:"RASProjectionFilename"`
+	Results   []rasMapLayer   `xml:"Results>Layer"`
+	MapLayers []rasMapLayer   `xml:"MapLayers>Layer"`
+	Terrains  []rasMapLayer   `xml:"Terrains>Layer"`
+	Sections  []rasMapSection `xml:",any"` // land cover and infiltration layers are stored in version specific elements
+}
+
+// rasMapSection any other element of the RAS Mapper file containing layers
+type rasMapSection struct {
+	XMLName xml.Name
+	Layers  []rasMapLayer `xml:"Layer"`
+}
+
+// Resolve a path relative to the RAS Mapper file e.g. '.\Terrain\Terrain.hdf'
+func rasMapPath(rasMapFile string, fn string) string {
+	if fn == "" || strings.Contains(fn, ":") {
+		// absolute windows paths cannot be resolved in the filestore
+		return fn
+	}
+	return filepath.Join(filepath.Dir(rasMapFile), strings.ReplaceAll(fn, `\`, "/"))
+}
+
+// Get the source rasters of a terrain from the attributes of its HDF file
+func getTerrainSourceFiles(rm *RasModel, fn string) ([]string, error) {
+	sourceFiles := []string{}
+
+	h, err := openHDF(rm.FileStore, fn)
+	if err != nil {
+		return sourceFiles, errors.Wrap(err, 0)
+	}
+	defer h.Close()
+
+	for _, source := range h.attributes("Terrain", "File") {
+		sourceFiles = append(sourceFiles, rasMapPath(fn, source))
+	}
+	return sourceFiles, nil
+}
+
+// Decodes the RAS Mapper file of the model, the returned path is empty if the model has none
+func readRasMapFile(rm *RasModel) (rasMapXML, string, error) {
+	var rmf rasMapXML
+	rasMapFile := strings.TrimSuffix(rm.Metadata.ProjFilePath, ".prj") + ".rasmap"
+	if !stringInSlice(rasMapFile, rm.FileList) {
+		return rmf, "", nil
+	}
+
+	f, err := rm.FileStore.GetObject(rasMapFile)
+	if err != nil {
+		return rmf, "", errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	if err := xml.NewDecoder(f).Decode(&rmf); err != nil {
+		return rmf, "", errors.Wrap(err, 0)
+	}
+	return rmf, rasMapFile, nil
+}
+
+// Reads the RAS Mapper file of the model
+func getRasMapData(rm *RasModel) error {
+	rmf, rasMapFile, err := readRasMapFile(rm)
+	if err != nil || rasMapFile == "" {
+		return err
+	}
+
+	contents := RasMapContents{
+		Path:               rasMapFile,
+		Projection:         rasMapPath(rasMapFile, rmf.Projection.Filename),
+		Terrains:           []TerrainLayer{},
+		LandCoverLayers:    []RasMapLayer{},
+		InfiltrationLayers: []RasMapLayer{},
+		Results:            []ResultsLayer{},
+	}
+
+	for _, layer := range rmf.Terrains {
+		terrain := TerrainLayer{Name: layer.Name, Filename: rasMapPath(rasMapFile, layer.Filename), SourceFiles: []string{}}
+		if stringInSlice(terrain.Filename, rm.DirectoryFiles) {
+			sourceFiles, err := getTerrainSourceFiles(rm, terrain.Filename)
+			if err != nil {
+				log.Println("RAS Mapper| terrain", terrain.Filename, err)
+			}
+			terrain.SourceFiles = append(terrain.SourceFiles, sourceFiles...)
+		}
+		contents.Terrains = append(contents.Terrains, terrain)
+	}
+
+	for _, layer := range rmf.Results {
+		results := ResultsLayer{Name: layer.Name, Filename: rasMapPath(rasMapFile, layer.Filename), Maps: []ResultsMap{}}
+		for _, m := range layer.Layers {
+			if m.Type != "RASResultsMap" {
+				continue
+			}
+			results.Maps = append(results.Maps, ResultsMap{
+				Name:           m.Name,
+				MapType:        m.MapParameters.MapType,
+				Profile:        m.MapParameters.ProfileName,
+				OutputMode:     m.MapParameters.OutputMode,
+				StoredFilename: rasMapPath(rasMapFile, m.MapParameters.StoredFilename),
+			})
+		}
+		contents.Results = append(contents.Results, results)
+	}
+
+	// layers can also be associated with a geometry e.g. a land cover layer within a RASGeometry layer
+	var addLayers func(layers []rasMapLayer, geometry string)
+	addLayers = func(layers []rasMapLayer, geometry string) {
+		for _, layer := range layers {
+			rasMapLayer := RasMapLayer{Name: layer.Name, Filename: rasMapPath(rasMapFile, layer.Filename), Geometry: geometry}
+			switch layer.Type {
+			case "LandCoverLayer":
+				contents.LandCoverLayers = append(contents.LandCoverLayers, rasMapLayer)
+			case "InfiltrationLayer":
+				contents.InfiltrationLayers = append(contents.InfiltrationLayers, rasMapLayer)
+			case "RASGeometry":
+				addLayers(layer.Layers, rasMapLayer.Filename)
+				continue
+			}
+			addLayers(layer.Layers, geometry)
+		}
+	}
+	addLayers(rmf.MapLayers, "")
+	for _, section := range rmf.Sections {
+		addLayers(section.Layers, "")
+	}
+
+	rm.Metadata.RasMapContents = contents
+	return nil
+}
+
+// Set the land cover layer of 2D areas from the land cover layer associated with their geometry in the RAS Mapper file
+func setTwoDAreaLandCover(rm *RasModel) {
+	for _, layer := range rm.Metadata.RasMapContents.LandCoverLayers {
+		if layer.Geometry == "" {
+			continue
+		}
+		for _, g := range rm.Metadata.GeomFiles {
+			if strings.TrimPrefix(g.Path+".hdf", "/") != strings.TrimPrefix(layer.Geometry, "/") {
+				continue
+			}
+			for areaName, area := range g.TwoDAreas {
+				area.LandCover = layer.Filename
+				g.TwoDAreas[areaName] = area
+			}
+		}
+	}
+}
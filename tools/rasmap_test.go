@@ -0,0 +1,83 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func TestGetRasMapData(t *testing.T) {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm := RasModel{
+		FileStore: fs,
+		FileList:  []string{"testdata/Muncie.prj", "testdata/Muncie.rasmap"},
+		Metadata:  ProjectMetadata{ProjFilePath: "testdata/Muncie.prj"},
+	}
+
+	if err := getRasMapData(&rm); err != nil {
+		t.Fatal(err)
+	}
+
+	// terrain source files are only read from terrain HDF files in the model directory
+	want := RasMapContents{
+		Path:       "testdata/Muncie.rasmap",
+		Projection: "testdata/GIS_Data/Muncie_IA_Clip.prj",
+		Terrains: []TerrainLayer{
+			{Name: "Terrain", Filename: "testdata/Terrain/Terrain.hdf", SourceFiles: []string{}},
+			{Name: "Survey", Filename: `C:\Survey\Terrain.hdf`, SourceFiles: []string{}},
+		},
+		LandCoverLayers:    []RasMapLayer{{Name: "Land Cover", Filename: "testdata/Land Classification/LandCover.hdf", Geometry: "testdata/Muncie.g04.hdf"}},
+		InfiltrationLayers: []RasMapLayer{{Name: "Soils", Filename: "testdata/Soils/Infiltration.hdf"}},
+		Results: []ResultsLayer{{
+			Name:     "Unsteady Multiple 2D Areas",
+			Filename: "testdata/Muncie.p04.hdf",
+			Maps: []ResultsMap{
+				{Name: "Depth", MapType: "depth", Profile: "Max", OutputMode: "Stored Current Terrain", StoredFilename: "testdata/Unsteady Multiple 2D Areas/Depth (Max).vrt"},
+				{Name: "Velocity", MapType: "velocity", Profile: "Max", OutputMode: "Dynamic"},
+			},
+		}},
+	}
+	if got := rm.Metadata.RasMapContents; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestGetRasMapDataWithoutRasMap(t *testing.T) {
+	rm := RasModel{Metadata: ProjectMetadata{ProjFilePath: "testdata/Muncie.prj"}}
+
+	if err := getRasMapData(&rm); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(rm.Metadata.RasMapContents, RasMapContents{}) {
+		t.Errorf("got %+v", rm.Metadata.RasMapContents)
+	}
+}
+
+func TestSetTwoDAreaLandCover(t *testing.T) {
+	rm := RasModel{Metadata: ProjectMetadata{
+		GeomFiles: []GeomFileContents{
+			{Path: "testdata/Muncie.g02", TwoDAreas: map[string]TwoDArea{"Perimeter 1": {}}},
+			{Path: "testdata/Muncie.g04", TwoDAreas: map[string]TwoDArea{"Perimeter 1": {}, "Perimeter 2": {}}},
+		},
+		RasMapContents: RasMapContents{LandCoverLayers: []RasMapLayer{
+			{Name: "NLCD", Filename: "testdata/Land Classification/NLCD.hdf"},
+			{Name: "Land Cover", Filename: "testdata/Land Classification/LandCover.hdf", Geometry: "testdata/Muncie.g04.hdf"},
+		}},
+	}}
+
+	setTwoDAreaLandCover(&rm)
+
+	// land cover layers are only assigned to the geometry they are associated with
+	if got := rm.Metadata.GeomFiles[0].TwoDAreas["Perimeter 1"].LandCover; got != "" {
+		t.Errorf("got land cover %s for a geometry without one", got)
+	}
+	for areaName, area := range rm.Metadata.GeomFiles[1].TwoDAreas {
+		if area.LandCover != "testdata/Land Classification/LandCover.hdf" {
+			t.Errorf("%s: got land cover %s", areaName, area.LandCover)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+// Structs and functions used to summarize HEC-RAS plan HDF5 results (.p##.hdf).
+
+package tools
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+const (
+	unsteadySummaryGroup  = "Results/Unsteady/Summary"
+	volumeAccountingGroup = "Results/Unsteady/Summary/Volume Accounting"
+	summaryOutputGroup    = "Results/Unsteady/Output/Output Blocks/Base Output/Summary Output"
+	twoDGeometryGroup     = "Geometry/2D Flow Areas"
+)
+
+// PlanResults summary of a plan's HDF5 output
+type PlanResults struct {
+	PlanFile         string                    `json:"plan_file"`
+	Solution         string                    `json:"solution,omitempty"`
+	ComputationTime  string                    `json:"computation_time,omitempty"`
+	RunTimeWindow    string                    `json:"run_time_window,omitempty"`
+	VolumeAccounting *VolumeAccounting         `json:"volume_accounting,omitempty"`
+	CrossSections    []XSResult                `json:"cross_sections,omitempty"`
+	TwoDAreas        map[string]TwoDAreaResult `json:"2d_areas,omitempty"`
+	Notes            string                    `json:"notes,omitempty"`
+}
+
+// Volume accounting of the whole model
+type VolumeAccounting struct {
+	StartingVolume float64 `json:"starting_volume"`
+	EndingVolume   float64 `json:"ending_volume"`
+	InflowVolume   float64 `json:"inflow_volume"`
+	OutflowVolume  float64 `json:"outflow_volume"`
+	Error          float64 `json:"error"`
+	ErrorPercent   float64 `json:"error_percent"`
+}
+
+// Maximum water surface at a 1D cross section
+type XSResult struct {
+	River       string  `json:"river,omitempty"`
+	Reach       string  `json:"reach,omitempty"`
+	RS          string  `json:"river_station,omitempty"`
+	MaxWS       float64 `json:"max_water_surface"`
+	TimeOfMaxWS float64 `json:"time_of_max_water_surface"` // days since simulation start
+}
+
+// Maximum depth and velocity statistics of a 2D flow area
+type TwoDAreaResult struct {
+	NumCells        int     `json:"num_cells"`
+	NumWetCells     int     `json:"num_wet_cells"`
+	MaxWS           float64 `json:"max_water_surface"`
+	MaxDepth        float64 `json:"max_depth"`
+	MeanMaxDepth    float64 `json:"mean_max_depth"` // mean of the maximum depth of wet cells
+	MaxVelocity     float64 `json:"max_velocity"`
+	MeanMaxVelocity float64 `json:"mean_max_velocity"` // mean of the maximum velocity of all faces
+}
+
+// getXSOrder returns 'River, Reach, RS' of every cross section in the order they are stored in the geometry file,
+// which is the order HEC-RAS writes 1D cross section results to the plan HDF5 file
+func getXSOrder(rm *RasModel, fn string) ([][3]string, error) {
+	xsOrder := [][3]string{}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return xsOrder, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	var river, reach string
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "River Reach="):
+			riverReach := strings.Split(rightofEquals(line), ",")
+			river = strings.TrimSpace(riverReach[0])
+			reach = strings.TrimSpace(riverReach[1])
+
+		case strings.HasPrefix(line, "Type RM Length L Ch R = 1"):
+			rs := strings.TrimSpace(strings.Split(rightofEquals(line), ",")[1])
+			xsOrder = append(xsOrder, [3]string{river, reach, rs})
+		}
+	}
+	return xsOrder, nil
+}
+
+// getXSResults gets maximum water surface of all 1D cross sections
+func getXSResults(h hdfFile, xsOrder [][3]string) ([]XSResult, error) {
+	results := []XSResult{}
+
+	path := summaryOutputGroup + "/Cross Sections/Maximum Water Surface"
+	if !h.hasDataset(path) {
+		return results, nil
+	}
+
+	rows, err := h.readDataset(path)
+	if err != nil {
+		return results, errors.Wrap(err, 0)
+	}
+	if len(rows) == 0 {
+		return results, nil
+	}
+
+	for i, maxWS := range rows[0] {
+		xs := XSResult{MaxWS: maxWS}
+		if len(rows) > 1 {
+			xs.TimeOfMaxWS = rows[1][i]
+		}
+		// cross sections can only be identified if the geometry file is consistent with the results
+		if len(xsOrder) == len(rows[0]) {
+			xs.River, xs.Reach, xs.RS = xsOrder[i][0], xsOrder[i][1], xsOrder[i][2]
+		}
+		results = append(results, xs)
+	}
+	return results, nil
+}
+
+// getTwoDAreaResult computes maximum depth and velocity statistics of a 2D flow area.
+// Statistics of missing datasets are skipped, the paths of the missing datasets are returned
+func getTwoDAreaResult(h hdfFile, area string) (TwoDAreaResult, []string, error) {
+	result := TwoDAreaResult{}
+	missing := []string{}
+
+	paths := map[string]string{
+		"ws":       fmt.Sprintf("%s/2D Flow Areas/%s/Maximum Water Surface", summaryOutputGroup, area),
+		"elev":     fmt.Sprintf("%s/%s/Cells Minimum Elevation", twoDGeometryGroup, area),
+		"velocity": fmt.Sprintf("%s/2D Flow Areas/%s/Maximum Face Velocity", summaryOutputGroup, area),
+	}
+	values := map[string][]float64{}
+	for _, key := range []string{"ws", "elev", "velocity"} {
+		if !h.hasDataset(paths[key]) {
+			missing = append(missing, paths[key])
+			continue
+		}
+		rows, err := h.readDataset(paths[key])
+		if err != nil {
+			return result, missing, errors.Wrap(err, 0)
+		}
+		if len(rows) > 0 {
+			values[key] = rows[0]
+		}
+	}
+
+	maxWS, minElev := values["ws"], values["elev"]
+	result.NumCells = len(maxWS)
+	result.MaxWS = math.Inf(-1)
+	sumDepth := 0.0
+	for i, ws := range maxWS {
+		if math.IsNaN(ws) {
+			continue
+		}
+		result.MaxWS = math.Max(result.MaxWS, ws)
+		// perimeter cells do not have a minimum elevation
+		if i >= len(minElev) || math.IsNaN(minElev[i]) {
+			continue
+		}
+		depth := ws - minElev[i]
+		if depth > 0 {
+			result.NumWetCells++
+			sumDepth += depth
+			result.MaxDepth = math.Max(result.MaxDepth, depth)
+		}
+	}
+	if math.IsInf(result.MaxWS, -1) {
+		result.MaxWS = 0
+	}
+	if result.NumWetCells > 0 {
+		result.MeanMaxDepth = sumDepth / float64(result.NumWetCells)
+	}
+
+	sumVel := 0.0
+	nFaces := 0
+	for _, v := range values["velocity"] {
+		if math.IsNaN(v) {
+			continue
+		}
+		v = math.Abs(v)
+		result.MaxVelocity = math.Max(result.MaxVelocity, v)
+		sumVel += v
+		nFaces++
+	}
+	if nFaces > 0 {
+		result.MeanMaxVelocity = sumVel / float64(nFaces)
+	}
+
+	return result, missing, nil
+}
+
+// getPlanResults summarizes the HDF5 output of a plan
+func getPlanResults(rm *RasModel, plan PlanFileContents, hdfPath string) (PlanResults, error) {
+	pr := PlanResults{PlanFile: filepath.Base(plan.Path)}
+
+	h, err := openHDF(rm.FileStore, hdfPath)
+	if err != nil {
+		return pr, errors.Wrap(err, 0)
+	}
+	defer h.Close()
+
+	pr.Solution, _ = h.attribute(unsteadySummaryGroup, "Solution")
+	pr.ComputationTime, _ = h.attribute(unsteadySummaryGroup, "Computation Time Total")
+	pr.RunTimeWindow, _ = h.attribute(unsteadySummaryGroup, "Run Time Window")
+
+	if errorPercent, ok := h.floatAttribute(volumeAccountingGroup, "Error Percent"); ok {
+		va := VolumeAccounting{ErrorPercent: errorPercent}
+		va.Error, _ = h.floatAttribute(volumeAccountingGroup, "Error")
+		va.StartingVolume, _ = h.floatAttribute(volumeAccountingGroup, "Volume Starting")
+		va.EndingVolume, _ = h.floatAttribute(volumeAccountingGroup, "Volume Ending")
+		va.InflowVolume, _ = h.floatAttribute(volumeAccountingGroup, "Total Boundary Flux of Water In")
+		va.OutflowVolume, _ = h.floatAttribute(volumeAccountingGroup, "Total Boundary Flux of Water Out")
+		pr.VolumeAccounting = &va
+	}
+
+	if plan.GeomFile != "" {
+		geomPath := strings.TrimSuffix(rm.Metadata.ProjFilePath, "prj") + strings.TrimSpace(plan.GeomFile)
+		xsOrder, err := getXSOrder(rm, geomPath)
+		if err != nil {
+			pr.Notes += fmt.Sprintf("Could not read cross sections of %s. ", filepath.Base(geomPath))
+		}
+		pr.CrossSections, err = getXSResults(h, xsOrder)
+		if err != nil {
+			return pr, errors.Wrap(err, 0)
+		}
+	}
+
+	areas := h.childGroups(summaryOutputGroup + "/2D Flow Areas")
+	if len(areas) > 0 {
+		pr.TwoDAreas = make(map[string]TwoDAreaResult)
+	}
+	for _, area := range areas {
+		areaResult, missing, err := getTwoDAreaResult(h, area)
+		if err != nil {
+			return pr, errors.Wrap(err, 0)
+		}
+		if len(missing) > 0 {
+			pr.Notes += fmt.Sprintf("Skipped missing datasets of 2D area %s: %s. ", area, strings.Join(missing, ", "))
+		}
+		pr.TwoDAreas[area] = areaResult
+	}
+
+	if pr.Solution == "" && len(pr.CrossSections) == 0 && len(areas) == 0 {
+		pr.Notes += "No unsteady results found. "
+	}
+
+	return pr, nil
+}
+
+// Results summarizes the HDF5 output of every plan that has been computed
+func (rm *RasModel) Results() (map[string]PlanResults, error) {
+	results := make(map[string]PlanResults)
+
+	for _, plan := range rm.Metadata.PlanFiles {
+		hdfPath := plan.Path + ".hdf"
+		if !stringInSlice(hdfPath, rm.FileList) {
+			continue
+		}
+
+		pr, err := getPlanResults(rm, plan, hdfPath)
+		if err != nil {
+			return results, errors.Wrap(err, 0)
+		}
+		results[filepath.Base(hdfPath)] = pr
+	}
+
+	return results, nil
+}
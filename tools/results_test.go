@@ -0,0 +1,73 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetXSResults(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	xsOrder := [][3]string{{"White", "Muncie", "15696.24"}, {"White", "Muncie", "15485.51"}, {"White", "Muncie", "15370.18"}}
+	got, err := getXSResults(h, xsOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []XSResult{
+		{"White", "Muncie", "15696.24", 952.5, 0.5},
+		{"White", "Muncie", "15485.51", 951.25, 0.75},
+		{"White", "Muncie", "15370.18", 950.0, 1.0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// cross sections are not identified when the geometry does not match the results
+	got, err = getXSResults(h, xsOrder[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].RS != "" {
+		t.Errorf("unmatched geometry: got %+v", got)
+	}
+}
+
+func TestGetTwoDAreaResult(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	got, missing, err := getTwoDAreaResult(h, "Perimeter 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("unexpected missing datasets %v", missing)
+	}
+	want := TwoDAreaResult{
+		NumCells:        4,
+		NumWetCells:     3,
+		MaxWS:           950,
+		MaxDepth:        4,
+		MeanMaxDepth:    8.0 / 3,
+		MaxVelocity:     3,
+		MeanMaxVelocity: 1.5,
+	}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGetTwoDAreaResultMissingDatasets(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	got, missing, err := getTwoDAreaResult(h, "Perimeter 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 2 {
+		t.Errorf("got missing %v, want elevation and velocity datasets", missing)
+	}
+	want := TwoDAreaResult{NumCells: 2, MaxWS: 951}
+	if got != want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
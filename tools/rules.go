@@ -0,0 +1,156 @@
+// Structs and functions used to parse Rules boundary conditions of unsteady flow files.
+
+package tools
+
+import (
+	"bufio"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// Rules Boundary Condition Data
+type Rules struct {
+	Variables  []string   `json:"variables,omitempty"`
+	Operations []RuleNode `json:"operations"`
+}
+
+// Single node of the rules tree.
+// Type can be Conditional, Assignment, Gate Operation, Constraint, Comment, or Statement.
+type RuleNode struct {
+	Type      string     `json:"type"`
+	Text      string     `json:"text"`
+	Condition string     `json:"condition,omitempty"` // only exists for Conditional
+	Then      []RuleNode `json:"then,omitempty"`      // only exists for Conditional
+	Else      []RuleNode `json:"else,omitempty"`      // only exists for Conditional, 'Else If' branches are nested Conditionals
+}
+
+// Get condition from 'If (condition) Then' or 'Else If (condition) Then' statement
+func ruleCondition(statement string, keyword string) string {
+	condition := strings.TrimSpace(strings.TrimPrefix(statement, keyword))
+	if strings.HasSuffix(strings.ToLower(condition), " then") {
+		condition = strings.TrimSpace(condition[:len(condition)-len(" then")])
+	}
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(condition, "("), ")"))
+}
+
+// Check if a statement is a call of one of the given functions e.g. 'Min(Flow, 100)'
+func isRuleCall(lower string, functions ...string) bool {
+	for _, function := range functions {
+		if strings.HasPrefix(lower, function) && strings.HasPrefix(strings.TrimSpace(lower[len(function):]), "(") {
+			return true
+		}
+	}
+	return false
+}
+
+// Classify a rule statement that is not part of a conditional's syntax
+func ruleStatementType(statement string) string {
+	lower := strings.ToLower(statement)
+	switch {
+	case strings.HasPrefix(statement, "'") || strings.HasPrefix(statement, "//"):
+		return "Comment"
+	case strings.Contains(lower, "gate"):
+		return "Gate Operation"
+	case isRuleCall(lower, "min", "max", "minimum", "maximum"):
+		return "Constraint"
+	case strings.Contains(statement, "="):
+		return "Assignment"
+	}
+	return "Statement"
+}
+
+// Build rules tree from a list of rule statements
+func parseRuleStatements(statements []string) (Rules, error) {
+	rules := Rules{Operations: []RuleNode{}}
+
+	// branches are the node lists new statements are added to; conditionals are the open If blocks
+	root := &rules.Operations
+	branches := []*[]RuleNode{root}
+	conditionals := []*RuleNode{}
+
+	for _, statement := range statements {
+		lower := strings.ToLower(statement)
+		current := branches[len(branches)-1]
+
+		switch {
+		case strings.HasPrefix(lower, "if "), strings.HasPrefix(lower, "if("):
+			*current = append(*current, RuleNode{Type: "Conditional", Text: statement, Condition: ruleCondition(statement, statement[:2])})
+			node := &(*current)[len(*current)-1]
+			conditionals = append(conditionals, node)
+			branches = append(branches, &node.Then)
+
+		case strings.HasPrefix(lower, "else if"), strings.HasPrefix(lower, "elseif"):
+			if len(conditionals) == 0 {
+				return rules, errors.Errorf("Rule statement '%s' has no matching If statement.", statement)
+			}
+			keyword := statement[:len("else if")]
+			if strings.HasPrefix(lower, "elseif") {
+				keyword = statement[:len("elseif")]
+			}
+			// 'Else If' is a Conditional nested in the Else branch and closes with the same 'End If'
+			parent := conditionals[len(conditionals)-1]
+			parent.Else = append(parent.Else, RuleNode{Type: "Conditional", Text: statement, Condition: ruleCondition(statement, keyword)})
+			node := &parent.Else[len(parent.Else)-1]
+			conditionals[len(conditionals)-1] = node
+			branches[len(branches)-1] = &node.Then
+
+		case lower == "else":
+			if len(conditionals) == 0 {
+				return rules, errors.Errorf("Rule statement '%s' has no matching If statement.", statement)
+			}
+			branches[len(branches)-1] = &conditionals[len(conditionals)-1].Else
+
+		case strings.HasPrefix(lower, "end if"), strings.HasPrefix(lower, "endif"):
+			if len(conditionals) == 0 {
+				return rules, errors.Errorf("Rule statement '%s' has no matching If statement.", statement)
+			}
+			conditionals = conditionals[:len(conditionals)-1]
+			branches = branches[:len(branches)-1]
+
+		default:
+			nodeType := ruleStatementType(statement)
+			if nodeType == "Assignment" {
+				variable := strings.TrimSpace(strings.Split(statement, "=")[0])
+				if !stringInSlice(variable, rules.Variables) {
+					rules.Variables = append(rules.Variables, variable)
+				}
+			}
+			*current = append(*current, RuleNode{Type: nodeType, Text: statement})
+		}
+	}
+
+	if len(conditionals) != 0 {
+		return rules, errors.New("Rules have an If statement without a matching End If statement.")
+	}
+	return rules, nil
+}
+
+// Get Rules Boundary Condition data.
+// Returns at EOF or if new Unsteady element is encountered
+func getRulesData(sc *bufio.Scanner) (rules Rules, description string, skipScan bool, err error) {
+	statements := []string{}
+
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		if stringInSlice(loe, unsteadyElementsPrefix[:]) {
+			skipScan = true
+			break
+		}
+
+		switch loe {
+		case "Rule Description":
+			description = strings.TrimSpace(strings.SplitN(line, "=", 2)[1])
+		case "Rule Text":
+			statement := strings.TrimSpace(strings.SplitN(line, "=", 2)[1])
+			if statement != "" {
+				statements = append(statements, statement)
+			}
+		}
+	}
+
+	rules, err = parseRuleStatements(statements)
+	return
+}
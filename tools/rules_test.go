@@ -0,0 +1,60 @@
+package tools
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetRulesData(t *testing.T) {
+	ud := readTestUnsteadyData(t, "testdata/Rules.u01")
+
+	bcs := ud.BoundaryConditions.Reaches["White - Muncie"]
+	if len(bcs) != 2 {
+		t.Fatalf("got %d boundary conditions, want 2: %+v", len(bcs), bcs)
+	}
+	if bcs[0].RS != "10000" || bcs[0].Type != "Rules" || bcs[0].Description != "Gate operations at high flows" {
+		t.Errorf("got rules boundary condition %+v", bcs[0])
+	}
+	if bcs[1].RS != "0.2" || bcs[1].Type != "Normal Depth" {
+		t.Errorf("got boundary condition after the rules %+v", bcs[1])
+	}
+
+	want := Rules{
+		Variables: []string{"Minimum Flow", "Outflow"},
+		Operations: []RuleNode{
+			{Type: "Comment", Text: "' Open the gate at high flows"},
+			{Type: "Assignment", Text: "Minimum Flow = 10"},
+			{
+				Type:      "Conditional",
+				Text:      "If (Flow > 1000) Then",
+				Condition: "Flow > 1000",
+				Then:      []RuleNode{{Type: "Gate Operation", Text: "Gate Opening = 5"}},
+				Else: []RuleNode{{
+					Type:      "Conditional",
+					Text:      "ElseIf (Flow > 500) Then",
+					Condition: "Flow > 500",
+					Then:      []RuleNode{{Type: "Gate Operation", Text: "Gate Opening = 2"}},
+					Else: []RuleNode{
+						{Type: "Assignment", Text: "Outflow = Flow"},
+						{Type: "Constraint", Text: "Max(Outflow, Minimum Flow)"},
+					},
+				}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(bcs[0].Data, want) {
+		t.Errorf("got rules %+v, want %+v", bcs[0].Data, want)
+	}
+}
+
+func TestParseRuleStatementsUnmatched(t *testing.T) {
+	for _, statements := range [][]string{
+		{"If (Flow > 1000) Then", "Gate Opening = 5"},
+		{"Else", "Gate Opening = 5"},
+		{"Gate Opening = 5", "End If"},
+	} {
+		if _, err := parseRuleStatements(statements); err == nil {
+			t.Errorf("expected an error for %q", statements)
+		}
+	}
+}
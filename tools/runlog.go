@@ -0,0 +1,190 @@
+// Structs and functions used to parse HEC-RAS computation messages and run logs (.p##.computeMsgs.txt, .bco##).
+
+package tools
+
+import (
+	"bufio"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-errors/errors" // warning: replaces standard errors
+)
+
+// RunLog diagnostics extracted from a computation log
+type RunLog struct {
+	File               string         `json:"file"`
+	VolumeErrorPercent *float64       `json:"volume_error_percent,omitempty"`
+	Counts             map[string]int `json:"counts"` // number of messages of each type
+	Messages           []RunMessage   `json:"messages"`
+}
+
+// RunMessage single warning or error of a computation log
+type RunMessage struct {
+	Type  string `json:"type"`
+	Text  string `json:"text"`
+	River string `json:"river,omitempty"`
+	Reach string `json:"reach,omitempty"`
+	RS    string `json:"river_station,omitempty"`
+	Area  string `json:"2d_area,omitempty"`
+	Cell  *int   `json:"cell,omitempty"`
+}
+
+// Message types in the order they are checked, the first match wins
+var runMessageTypes = []struct {
+	Type string
+	RE   *regexp.Regexp
+}{
+	{"Divided Flow", regexp.MustCompile(`(?i)divided flow`)},
+	{"Critical Depth", regexp.MustCompile(`(?i)critical depth`)},
+	{"Max Iterations", regexp.MustCompile(`(?i)max(imum)?(\s+number\s+of)?\s+iter|iterations\s+exceeded|did not converge`)},
+	{"Error", regexp.MustCompile(`(?i)\berror\b`)},
+	{"Warning", regexp.MustCompile(`(?i)\bwarning\b|\bunstable\b|\binstability\b`)},
+}
+
+// Paths of the compute messages dataset of plan HDF5 files, older versions of HEC-RAS store it under Results/Unsteady
+var computeMessagesPaths = []string{"Results/Summary/Compute Messages (text)", "Results/Unsteady/Summary/Compute Messages (text)"}
+
+var (
+	volumeErrorRE = regexp.MustCompile(`(?i)volume\s+accounting\s+error\s+(as\s+percentage|percent(age)?|%)\s*:?\s*(-?[0-9]*\.?[0-9]+([eE][-+]?[0-9]+)?)`)
+	xsLocationRE  = regexp.MustCompile(`(?i)river\s*:\s*(.+?)\s+reach\s*:\s*(.+?)\s+rs\s*:\s*([^\s,]+)`)
+	areaRE        = regexp.MustCompile(`(?i)(2d\s+flow\s+area|storage\s+area|area)\s*:\s*(.+?)(\s{2,}|,|\s+cell|$)`)
+	cellRE        = regexp.MustCompile(`(?i)cell\s*[:#]?\s*([0-9]+)`)
+)
+
+// Get the river/reach/station or 2D area/cell a message refers to
+func runMessageLocation(msg *RunMessage) {
+	if match := xsLocationRE.FindStringSubmatch(msg.Text); match != nil {
+		msg.River, msg.Reach, msg.RS = strings.TrimSpace(match[1]), strings.TrimSpace(match[2]), match[3]
+		return
+	}
+	if match := cellRE.FindStringSubmatch(msg.Text); match != nil {
+		if cell, err := strconv.Atoi(match[1]); err == nil {
+			msg.Cell = &cell
+		}
+		if match := areaRE.FindStringSubmatch(msg.Text); match != nil {
+			msg.Area = strings.TrimSpace(match[2])
+		}
+	}
+}
+
+// Parse a line of a computation log, only lines that are warnings or errors are kept as messages
+func (rl *RunLog) parseLine(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return
+	}
+
+	if match := volumeErrorRE.FindStringSubmatch(line); match != nil {
+		volumeError, err := parseFloat(match[3], 64)
+		if err == nil {
+			rl.VolumeErrorPercent = &volumeError
+		}
+		return
+	}
+
+	for _, mt := range runMessageTypes {
+		if !mt.RE.MatchString(line) {
+			continue
+		}
+		msg := RunMessage{Type: mt.Type, Text: line}
+		runMessageLocation(&msg)
+		rl.Messages = append(rl.Messages, msg)
+		rl.Counts[mt.Type]++
+		return
+	}
+}
+
+// Parse a computation log.
+// Only lines that are warnings or errors are kept as messages.
+func getRunLog(rm *RasModel, fn string) (RunLog, error) {
+	rl := RunLog{File: filepath.Base(fn), Counts: make(map[string]int), Messages: []RunMessage{}}
+
+	f, err := rm.FileStore.GetObject(fn)
+	if err != nil {
+		return rl, errors.Wrap(err, 0)
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		rl.parseLine(sc.Text())
+	}
+	if err := sc.Err(); err != nil {
+		return rl, errors.Wrap(err, 0)
+	}
+
+	return rl, nil
+}
+
+// Parse the compute messages stored in a plan HDF5 file.
+// The volume accounting error attribute is used when the messages do not report it.
+func getHDFRunLog(h hdfFile, fn string) RunLog {
+	rl := RunLog{File: filepath.Base(fn), Counts: make(map[string]int), Messages: []RunMessage{}}
+
+	for _, path := range computeMessagesPaths {
+		// the messages are a single string holding every line of the log
+		messages, err := h.readStrings(path)
+		if err != nil {
+			continue
+		}
+		for _, text := range messages {
+			for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+				rl.parseLine(line)
+			}
+		}
+		break
+	}
+
+	if rl.VolumeErrorPercent == nil {
+		if volumeError, ok := h.floatAttribute(volumeAccountingGroup, "Error Percent"); ok {
+			rl.VolumeErrorPercent = &volumeError
+		}
+	}
+	return rl
+}
+
+// RunLogs parses the computation logs of every plan.
+// Plans without a text log are parsed from the compute messages of their HDF5 file.
+func (rm *RasModel) RunLogs() (map[string]RunLog, error) {
+	runLogs := make(map[string]RunLog)
+	loggedPlans := []string{}
+
+	for _, fp := range rm.DirectoryFiles {
+		name := filepath.Base(fp)
+		if !RasRE.ComputeLog.MatchString(name) && !RasRE.BCOutput.MatchString(filepath.Ext(fp)) {
+			continue
+		}
+
+		rl, err := getRunLog(rm, fp)
+		if err != nil {
+			return runLogs, errors.Wrap(err, 0)
+		}
+		runLogs[name] = rl
+
+		if RasRE.ComputeLog.MatchString(name) {
+			loggedPlans = append(loggedPlans, RasRE.ComputeLog.ReplaceAllStringFunc(fp, func(s string) string {
+				return s[:len(".p##")]
+			}))
+		}
+	}
+
+	for _, plan := range rm.Metadata.PlanFiles {
+		hdfPath := plan.Path + ".hdf"
+		if stringInSlice(plan.Path, loggedPlans) || !stringInSlice(hdfPath, rm.DirectoryFiles) {
+			continue
+		}
+
+		h, err := openHDF(rm.FileStore, hdfPath)
+		if err != nil {
+			return runLogs, errors.Wrap(err, 0)
+		}
+		rl := getHDFRunLog(h, hdfPath)
+		h.Close()
+		runLogs[rl.File] = rl
+	}
+
+	return runLogs, nil
+}
@@ -0,0 +1,61 @@
+package tools
+
+import "testing"
+
+func TestGetHDFRunLog(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p04.hdf")
+
+	rl := getHDFRunLog(h, "/models/Muncie/Muncie.p04.hdf")
+	if rl.File != "Muncie.p04.hdf" {
+		t.Errorf("got file %s", rl.File)
+	}
+	if rl.VolumeErrorPercent == nil || *rl.VolumeErrorPercent != 0.0123 {
+		t.Errorf("volume error should be read from the messages, got %v", rl.VolumeErrorPercent)
+	}
+	if len(rl.Messages) != 1 {
+		t.Fatalf("got %d messages, want 1: %+v", len(rl.Messages), rl.Messages)
+	}
+
+	xsMsg := rl.Messages[0]
+	if xsMsg.Type != "Max Iterations" || xsMsg.River != "White" || xsMsg.Reach != "Muncie" || xsMsg.RS != "15485.51" {
+		t.Errorf("got %+v", xsMsg)
+	}
+	if rl.Counts["Max Iterations"] != 1 {
+		t.Errorf("got counts %v", rl.Counts)
+	}
+}
+
+func TestGetHDFRunLogWithoutMessages(t *testing.T) {
+	h := openTestHDF(t, "Muncie.p05.hdf")
+
+	rl := getHDFRunLog(h, "Muncie.p05.hdf")
+	if len(rl.Messages) != 0 {
+		t.Errorf("got messages %+v", rl.Messages)
+	}
+	if rl.VolumeErrorPercent == nil || *rl.VolumeErrorPercent != 0.5 {
+		t.Errorf("volume error should fall back to the volume accounting attribute, got %v", rl.VolumeErrorPercent)
+	}
+}
+
+func TestRunLogParseLine(t *testing.T) {
+	rl := RunLog{Counts: make(map[string]int), Messages: []RunMessage{}}
+	for _, line := range []string{
+		"",
+		"Simulation started at: 15Jan2024 10:31:02 AM",
+		"  2D Flow Area: Perimeter 1 Cell: 1254 water surface unstable",
+		"Overall Volume Accounting Error as percentage: 0.0123",
+	} {
+		rl.parseLine(line)
+	}
+
+	if len(rl.Messages) != 1 {
+		t.Fatalf("got %d messages, want 1: %+v", len(rl.Messages), rl.Messages)
+	}
+	msg := rl.Messages[0]
+	if msg.Type != "Warning" || msg.Area != "Perimeter 1" || msg.Cell == nil || *msg.Cell != 1254 {
+		t.Errorf("got %+v", msg)
+	}
+	if rl.VolumeErrorPercent == nil || *rl.VolumeErrorPercent != 0.0123 {
+		t.Errorf("got volume error %v", rl.VolumeErrorPercent)
+	}
+}
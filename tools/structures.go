@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/USACE/filestore"
 	"github.com/go-errors/errors" // warning: replaces standard errors
 )
 
@@ -453,12 +454,10 @@ func getGates(nextLine string) (gates, error) {
 }
 
 // Extract data from Inline Structures
-func getWeirData(rm *RasModel, fn string, i int) (weirs, error) {
-	weir := weirs{}
-
-	f, err := rm.FileStore.GetObject(fn)
+func getWeirData(fs filestore.FileStore, fn string, i int) (weirs, error) {
+	f, err := fs.GetObject(fn)
 	if err != nil {
-		return weir, errors.Wrap(err, 0)
+		return weirs{}, errors.Wrap(err, 0)
 
 	}
 	defer f.Close()
@@ -469,89 +468,98 @@ func getWeirData(rm *RasModel, fn string, i int) (weirs, error) {
 	for wSc.Scan() {
 		wi++
 		if wi == i {
-			lineData := strings.Split(rightofEquals(wSc.Text()), ",")
-			station, err := parseFloat(strings.TrimSpace(lineData[1]), 64)
+			return scanWeirData(wSc, strings.Split(rightofEquals(wSc.Text()), ","))
+		}
+	}
+	return weirs{}, nil
+}
+
+// Extract data from an Inline Structure, the scanner must be at the line after the structure's 'Type RM Length L Ch R' line
+func scanWeirData(wSc *bufio.Scanner, lineData []string) (weirs, error) {
+	weir := weirs{}
+
+	station, err := parseFloat(strings.TrimSpace(lineData[1]), 64)
+	if err != nil {
+		return weir, errors.Wrap(err, 0)
+
+	}
+	weir.Station = station
+
+	for wSc.Scan() {
+		line := wSc.Text()
+		switch {
+		case strings.HasPrefix(line, "BEGIN DESCRIPTION"):
+			description, _, err := getDescription(wSc, 0, "END DESCRIPTION:")
 			if err != nil {
 				return weir, errors.Wrap(err, 0)
 
 			}
-			weir.Station = station
-		} else if wi > i {
-			line := wSc.Text()
-			switch {
-			case strings.HasPrefix(line, "BEGIN DESCRIPTION"):
-				description, _, err := getDescription(wSc, 0, "END DESCRIPTION:")
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
+			weir.Description += description
 
-				}
-				weir.Description += description
-
-			case strings.HasPrefix(line, "Node Name="):
-				weir.Name = rightofEquals(line)
+		case strings.HasPrefix(line, "Node Name="):
+			weir.Name = rightofEquals(line)
 
-			case strings.HasPrefix(line, "#Inline Weir SE="):
-				nElev, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
-				}
-				nLines := numberofLines(nElev*2, 80, 8)
+		case strings.HasPrefix(line, "#Inline Weir SE="):
+			nElev, err := strconv.Atoi(strings.TrimSpace(rightofEquals(line)))
+			if err != nil {
+				return weir, errors.Wrap(err, 0)
+			}
+			nLines := numberofLines(nElev*2, 80, 8)
 
-				elev, _, err := getMaxMinElev(wSc, 0, nLines, 0, 80, 8, 2)
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
+			elev, _, err := getMaxMinElev(wSc, 0, nLines, 0, 80, 8, 2)
+			if err != nil {
+				return weir, errors.Wrap(err, 0)
 
-				}
-				weir.WeirElev = elev
+			}
+			weir.WeirElev = elev
 
-			case strings.HasPrefix(line, "IW Dist,WD"):
-				wSc.Scan()
-				nextLineData := strings.Split(wSc.Text(), ",")
-				weirWidth, err := parseFloat(strings.TrimSpace(nextLineData[1]), 64)
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
+		case strings.HasPrefix(line, "IW Dist,WD"):
+			wSc.Scan()
+			nextLineData := strings.Split(wSc.Text(), ",")
+			weirWidth, err := parseFloat(strings.TrimSpace(nextLineData[1]), 64)
+			if err != nil {
+				return weir, errors.Wrap(err, 0)
 
-				}
-				weir.WeirWidth = weirWidth
+			}
+			weir.WeirWidth = weirWidth
 
-			case strings.HasPrefix(line, "IW Gate Name"):
-				wSc.Scan()
-				gate, err := getGates(wSc.Text())
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
+		case strings.HasPrefix(line, "IW Gate Name"):
+			wSc.Scan()
+			gate, err := getGates(wSc.Text())
+			if err != nil {
+				return weir, errors.Wrap(err, 0)
 
-				}
-				weir.Gates = append(weir.Gates, gate)
-				weir.NumGates++
+			}
+			weir.Gates = append(weir.Gates, gate)
+			weir.NumGates++
 
-			case strings.HasPrefix(line, "IW Culv="):
-				conduit, err := getConduits(line, false)
-				if err != nil {
-					return weir, errors.Wrap(err, 0)
+		case strings.HasPrefix(line, "IW Culv="):
+			conduit, err := getConduits(line, false)
+			if err != nil {
+				return weir, errors.Wrap(err, 0)
 
-				}
-				weir.Conduits = append(weir.Conduits, conduit)
-				weir.NumConduits++
+			}
+			weir.Conduits = append(weir.Conduits, conduit)
+			weir.NumConduits++
 
-			case strings.HasPrefix(line, "Type RM Length L Ch R ="):
-				return weir, nil
+		case strings.HasPrefix(line, "Type RM Length L Ch R ="):
+			return weir, nil
 
-			case strings.HasPrefix(line, "River Reach="):
-				return weir, nil
-			}
+		case strings.HasPrefix(line, "River Reach="):
+			return weir, nil
 		}
 	}
 	return weir, nil
 }
 
 // Extract all data from 1D Bridges, Culverts, and Inline Structures
-func getHydraulicStructureData(rm *RasModel, fn string, idx int) (hydraulicStructures, error) {
+func getHydraulicStructureData(fs filestore.FileStore, fn string, idx int) (hydraulicStructures, error) {
 	structures := hydraulicStructures{}
 	bData := bridgeData{}
 	cData := culvertData{}
 	wData := weirData{}
 
-	f, err := rm.FileStore.GetObject(fn)
+	f, err := fs.GetObject(fn)
 	if err != nil {
 		return structures, errors.Wrap(err, 0)
 
@@ -601,7 +609,7 @@ func getHydraulicStructureData(rm *RasModel, fn string, idx int) (hydraulicStruc
 					bData.NumBridges++
 
 				case 5:
-					weir, err := getWeirData(rm, fn, i)
+					weir, err := getWeirData(fs, fn, i)
 					if err != nil {
 						return structures, errors.Wrap(err, 0)
 
@@ -0,0 +1,183 @@
+"""Write the HDF5 test fixtures Muncie.p04.hdf and Muncie.p05.hdf without the HDF5 library.
+
+The files use the original HDF5 format (superblock version 0, version 1 object headers and symbol table groups)
+which every HDF5 reader supports. They hold a small subset of HEC-RAS plan HDF5 files:
+numeric datasets of one and two dimensions, a fixed length string dataset and string and numeric attributes.
+
+    python3 make_hdf.py
+"""
+
+import os
+import struct
+
+UNDEF = 0xFFFFFFFFFFFFFFFF
+GROUP_LEAF_K = 4  # symbol table nodes hold 2K entries
+GROUP_INTERNAL_K = 16  # B-tree nodes hold 2K children
+
+
+def pad8(b):
+    return b + b"\0" * (-len(b) % 8)
+
+
+def float32_type():
+    # little endian IEEE float, mantissa normalization implied, sign bit 31
+    return struct.pack("<BBBBI", 0x11, 0x20, 31, 0, 4) + struct.pack("<HHBBBBI", 0, 32, 23, 8, 0, 23, 127)
+
+
+def string_type(size):
+    # null terminated ascii string
+    return struct.pack("<BBBBI", 0x13, 0x00, 0, 0, size)
+
+
+def dataspace(dims):
+    # version 1, a rank of 0 is a scalar
+    return struct.pack("<BBBBI", 1, len(dims), 0, 0, 0) + b"".join(struct.pack("<Q", d) for d in dims)
+
+
+class Dataset:
+    def __init__(self, dims, values=None, strings=None, size=0):
+        self.dims = dims
+        if strings is not None:
+            self.dtype = string_type(size)
+            self.data = b"".join(s.encode().ljust(size, b"\0") for s in strings)
+        else:
+            self.dtype = float32_type()
+            self.data = b"".join(struct.pack("<f", v) for v in values)
+
+
+class Group:
+    def __init__(self, attrs=None):
+        self.attrs = attrs or {}
+        self.children = {}
+
+    def group(self, path):
+        g = self
+        for name in path.strip("/").split("/"):
+            g = g.children.setdefault(name, Group())
+        return g
+
+
+def attribute_message(name, value):
+    if isinstance(value, str):
+        dtype, data = string_type(len(value) + 1), value.encode() + b"\0"
+    else:
+        dtype, data = float32_type(), struct.pack("<f", value)
+    space = dataspace([])
+    name = name.encode() + b"\0"
+    return struct.pack("<BBHHH", 1, 0, len(name), len(dtype), len(space)) + pad8(name) + pad8(dtype) + pad8(space) + data
+
+
+class Writer:
+    def __init__(self):
+        self.buf = bytearray(96)  # superblock
+
+    def alloc(self, b):
+        addr = len(self.buf)
+        self.buf += pad8(bytes(b))
+        return addr
+
+    def object_header(self, messages):
+        body = b""
+        for msg_type, data in messages:
+            data = pad8(data)
+            body += struct.pack("<HHB3x", msg_type, len(data), 0) + data
+        return self.alloc(struct.pack("<BBHII4x", 1, 0, len(messages), 1, len(body)) + body)
+
+    def attributes(self, attrs):
+        return [(0x000C, attribute_message(name, value)) for name, value in attrs.items()]
+
+    def dataset(self, ds):
+        addr = self.alloc(ds.data)
+        layout = struct.pack("<BBQQ", 3, 1, addr, len(ds.data))
+        return self.object_header([(0x0001, dataspace(ds.dims)), (0x0003, ds.dtype), (0x0008, layout)])
+
+    def group(self, g):
+        """Write a group and its children, returns the object header, B-tree and local heap addresses"""
+        names = sorted(g.children, key=lambda n: n.encode())
+        if len(names) > 2 * GROUP_LEAF_K:
+            raise ValueError("groups are limited to a single symbol table node")
+
+        entries = []
+        heap = bytearray(8)  # offset 0 is the empty string
+        for name in names:
+            child = g.children[name]
+            offset = len(heap)
+            heap += pad8(name.encode() + b"\0")
+            if isinstance(child, Group):
+                header, btree, local_heap = self.group(child)
+                scratch = struct.pack("<IIQQ", 1, 0, btree, local_heap)
+            else:
+                header = self.dataset(child)
+                scratch = struct.pack("<II16x", 0, 0)
+            entries.append(struct.pack("<QQ", offset, header) + scratch)
+
+        heap_addr = len(self.buf)
+        self.alloc(b"HEAP" + struct.pack("<B3xQQQ", 0, len(heap), 1, heap_addr + 32) + heap)
+
+        node = b"SNOD" + struct.pack("<BBH", 1, 0, len(entries)) + b"".join(entries)
+        node_addr = self.alloc(node.ljust(8 + 2 * GROUP_LEAF_K * 40, b"\0"))
+
+        last_key = struct.unpack("<Q", entries[-1][:8])[0] if entries else 0
+        tree = b"TREE" + struct.pack("<BBHQQ", 0, 0, 1 if entries else 0, UNDEF, UNDEF)
+        if entries:
+            tree += struct.pack("<QQQ", 0, node_addr, last_key)
+        btree_addr = self.alloc(tree.ljust(24 + (2 * GROUP_INTERNAL_K + 1) * 8 + 2 * GROUP_INTERNAL_K * 8, b"\0"))
+
+        header = self.object_header([(0x0011, struct.pack("<QQ", btree_addr, heap_addr))] + self.attributes(g.attrs))
+        return header, btree_addr, heap_addr
+
+    def write(self, root):
+        header, btree, heap = self.group(root)
+        root_entry = struct.pack("<QQIIQQ", 0, header, 1, 0, btree, heap)
+        superblock = b"\x89HDF\r\n\x1a\n" + struct.pack("<BBBBBBBBHHI", 0, 0, 0, 0, 0, 8, 8, 0, GROUP_LEAF_K, GROUP_INTERNAL_K, 0)
+        superblock += struct.pack("<QQQQ", 0, UNDEF, len(self.buf), UNDEF) + root_entry
+        self.buf[:96] = superblock
+        return bytes(self.buf)
+
+
+def muncie():
+    root = Group({"File Type": "HEC-RAS Results", "File Version": "HEC-RAS 6.3.1 September 2022"})
+
+    area = root.group("Geometry/2D Flow Areas/Perimeter 1")
+    area.children["Cells Minimum Elevation"] = Dataset([4], [948.0, 946.0, 946.0, 942.0])
+
+    # mesh of a single cell without the cells minimum elevation
+    area = root.group("Geometry/2D Flow Areas/Perimeter 2")
+    area.children["Cells Center Coordinate"] = Dataset([1, 2], [5.0, 5.0])
+    area.children["Cells FacePoint Indexes"] = Dataset([1, 4], [0, 1, 2, 3])
+    area.children["FacePoints Coordinate"] = Dataset([4, 2], [0.0, 0.0, 10.0, 0.0, 10.0, 10.0, 0.0, 10.0])
+    area.children["Faces FacePoint Indexes"] = Dataset([4, 2], [0, 1, 1, 2, 2, 3, 3, 0])
+    area.children["Faces Minimum Elevation"] = Dataset([4], [950.0, 951.0, 952.0, 953.0])
+
+    messages = (
+        "Plan: 'Unsteady Multiple 2D Areas' (Muncie.p04)\r\n"
+        "WARNING: Maximum number of iterations exceeded River: White Reach: Muncie RS: 15485.51\r\n"
+        "Overall Volume Accounting Error as percentage: 0.0123\r\n"
+    )
+    root.group("Results/Summary").children["Compute Messages (text)"] = Dataset([1], strings=[messages], size=256)
+
+    summary = root.group("Results/Unsteady/Summary")
+    summary.attrs["Solution"] = "Unsteady Finished Successfully"
+    root.group("Results/Unsteady/Summary/Volume Accounting").attrs["Error Percent"] = 0.5
+
+    output = root.group("Results/Unsteady/Output/Output Blocks/Base Output/Summary Output")
+    output.group("Cross Sections").children["Maximum Water Surface"] = Dataset([2, 3], [952.5, 951.25, 950.0, 0.5, 0.75, 1.0])
+    perimeter = output.group("2D Flow Areas/Perimeter 1")
+    perimeter.children["Maximum Water Surface"] = Dataset([2, 4], [950.0, 948.0, 945.0, 946.0, 1.0, 1.0, 1.5, 2.0])
+    perimeter.children["Maximum Face Velocity"] = Dataset([2, 3], [1.5, -3.0, 0.0, 1.0, 1.25, 1.5])
+    # results without the face velocity
+    output.group("2D Flow Areas/Perimeter 2").children["Maximum Water Surface"] = Dataset([2, 2], [950.0, 951.0, 1.0, 1.0])
+    return root
+
+
+def muncie_without_messages():
+    root = Group({"File Type": "HEC-RAS Results"})
+    root.group("Results/Unsteady/Summary/Volume Accounting").attrs["Error Percent"] = 0.5
+    return root
+
+
+if __name__ == "__main__":
+    testdata = os.path.dirname(os.path.abspath(__file__))
+    for fn, root in [("Muncie.p04.hdf", muncie()), ("Muncie.p05.hdf", muncie_without_messages())]:
+        with open(os.path.join(testdata, fn), "wb") as f:
+            f.write(Writer().write(root))
@@ -19,16 +19,72 @@ var unsteadyElementsPrefix = [...]string{
 	"Flow Title",
 	"Program Version",
 	"Boundary Location",
+	"Use Restart",
+	"Restart Filename",
+	"Initial Flow Loc",
+	"Initial Storage Elev",
+	"Initial 2DArea Elev",
+	"Met Point Raster Parameters",
+	"Precipitation Mode",
+	"Wind Mode",
+	"Met BC",
+	"Observed Data",
 }
 
 // Unsteady Data
 type UnsteadyData struct {
 	FlowTitle          string
 	ProgramVersion     string
-	InitialConditions  interface{} // to be implemented
+	InitialConditions  InitialConditions
 	BoundaryConditions UnsteadyBoundaryConditions
-	MeterologicalData  interface{} // to be implemented
-	ObservedData       interface{} // to be implemented // added in version 6.2
+	MeterologicalData  MeteorologicalData
+	ObservedData       []ObservedTimeSeries // added in version 6.2
+}
+
+// Unsteady Initial Conditions
+type InitialConditions struct {
+	UseRestart            bool                `json:"use_restart"`
+	RestartFile           string              `json:"restart_file,omitempty"`
+	Flows                 map[string][]RSFlow `json:"flows,omitempty"` // keyed by River - Reach
+	StorageAreaElevations []StoAreaElevation  `json:"storage_area_elevations,omitempty"`
+	TwoDAreaElevations    []StoAreaElevation  `json:"2d_area_elevations,omitempty"`
+}
+
+// Unsteady Meteorological Data. Available in version 6.0 and later
+type MeteorologicalData struct {
+	PointRasterParameters string           `json:"point_raster_parameters,omitempty"`
+	PrecipitationMode     string           `json:"precipitation_mode,omitempty"` // Enable or Disable
+	WindMode              string           `json:"wind_mode,omitempty"`
+	Variables             map[string]MetBC `json:"variables,omitempty"` // keyed by Met BC variable e.g. Precipitation, Evapotranspiration, Wind Speed
+	Unparsed              []string         `json:"unparsed,omitempty"`  // Met BC lines whose variable and setting could not be determined
+}
+
+// Meteorological Boundary Condition of a single variable
+type MetBC struct {
+	Mode               string            `json:"mode,omitempty"` // e.g. None, Gridded, Point, Constant
+	ConstantValue      float64           `json:"constant_value,omitempty"`
+	ConstantUnits      string            `json:"constant_units,omitempty"`
+	PointInterpolation string            `json:"point_interpolation,omitempty"`
+	GriddedSource      string            `json:"gridded_source,omitempty"` // e.g. DSS, GDAL Raster File(s)
+	DSSFile            string            `json:"dss_file,omitempty"`
+	DSSPath            string            `json:"dss_path,omitempty"`
+	RasterFiles        string            `json:"raster_files,omitempty"`
+	OtherSettings      map[string]string `json:"other_settings,omitempty"` // settings not listed above, keyed by setting name
+}
+
+// Observed Time Series used for calibration. Added in version 6.2
+type ObservedTimeSeries struct {
+	Name         string `json:"name"`
+	Type         string `json:"type"` // e.g. Stage, Flow
+	Gage         string `json:"gage,omitempty"`
+	LocationType string `json:"location_type,omitempty"` // either Reach, Connection, Area, or PumpStation
+	Location     string `json:"location,omitempty"`
+	RS           string `json:",omitempty"`        // only exists for rivers
+	BCLine       string `json:"bc_line,omitempty"` // only exists for storage and 2D areas
+	TimeInterval string `json:"time_interval,omitempty"`
+	UseDSS       bool   `json:"use_dss"`
+	DSSFile      string `json:"dss_file,omitempty"`
+	DSSPath      string `json:"dss_path,omitempty"`
 }
 
 // Unsteady Boundary Conditions
@@ -67,6 +123,40 @@ type DateTime struct {
 	Hours string `json:"hours,omitempty"` // should not be int/float or else 0015 hours will become 15 hours
 }
 
+// Elevation Controlled Gate Data.
+// Gates open when the reference elevation (or elevation difference) rises above OpenElev and close when it falls below CloseElev.
+type ElevControlledGate struct {
+	ReferenceType      string  `json:"reference_type,omitempty"`      // e.g. Reference Elevation, Elevation Difference
+	ReferenceLocation  string  `json:"reference_location,omitempty"`  // River - Reach - RS or Storage Area
+	DownstreamLocation string  `json:"downstream_location,omitempty"` // only exists for Elevation Difference
+	OpenElev           float64 `json:"open_elevation"`
+	CloseElev          float64 `json:"close_elevation"`
+	OpenRate           float64 `json:"open_rate"`
+	CloseRate          float64 `json:"close_rate"`
+	MaxOpening         float64 `json:"max_opening"`
+	MinOpening         float64 `json:"min_opening"`
+	InitialOpening     float64 `json:"initial_opening"`
+}
+
+// Navigation Dam Data.
+// Mode can be Pool Only Control, Hinge Point Only Control, or Pool and Hinge Point Control.
+type NavigationDam struct {
+	Mode              string   `json:"mode,omitempty"`
+	Gates             []string `json:"gates,omitempty"`
+	HingePoint        string   `json:"hinge_point,omitempty"` // River - Reach - RS
+	TargetPoolElev    float64  `json:"target_pool_elevation"`
+	MinPoolElev       float64  `json:"min_pool_elevation"`
+	MaxPoolElev       float64  `json:"max_pool_elevation"`
+	TargetHingeElev   float64  `json:"target_hinge_elevation"`
+	MinHingeElev      float64  `json:"min_hinge_elevation"`
+	MaxHingeElev      float64  `json:"max_hinge_elevation"`
+	MaxGateRate       float64  `json:"max_gate_rate"`
+	MinGateChange     float64  `json:"min_gate_change"`
+	FlowLowThreshold  float64  `json:"flow_low_threshold"`
+	FlowHighThreshold float64  `json:"flow_high_threshold"`
+	InitialOpening    float64  `json:"initial_opening"`
+}
+
 // Parse Unsteady Boundary Condition's header.
 func parseUnsteadyBCHeader(line string) (parentType string, parent string, flowEndRS string, bc BoundaryCondition, err error) {
 	bcArray := strings.Split(rightofEquals(line), ",")
@@ -94,6 +184,83 @@ func parseUnsteadyBCHeader(line string) (parentType string, parent string, flowE
 	return
 }
 
+// Parse Initial Flow line e.g. 'Initial Flow Loc=Butte Cr.       ,Tributary       ,0.2     ,500'
+func parseInitialFlow(line string) (reach string, rsFlow RSFlow, err error) {
+	ifArray := strings.Split(rightofEquals(line), ",")
+	if len(ifArray) < 4 || strings.TrimSpace(ifArray[0]) == "" {
+		err = errors.Errorf("Cannot determine River/Reach name, station and flow at line '%s'.", line)
+		return
+	}
+	reach = fmt.Sprintf("%s - %s", strings.TrimSpace(ifArray[0]), strings.TrimSpace(ifArray[1]))
+	rsFlow.RS = strings.TrimSpace(ifArray[2])
+	rsFlow.Flow, err = parseFloat(strings.TrimSpace(ifArray[3]), 64)
+	return
+}
+
+// Parse Initial Elevation line of Storage and 2D Areas e.g. 'Initial Storage Elev=Upper SA        ,4000'
+func parseInitialElevation(line string) (StoAreaElevation, error) {
+	ieArray := strings.Split(rightofEquals(line), ",")
+	if len(ieArray) < 2 {
+		return StoAreaElevation{}, errors.Errorf("Cannot determine area name and elevation at line '%s'.", line)
+	}
+	elev, err := parseFloat(strings.TrimSpace(ieArray[1]), 64)
+	if err != nil {
+		return StoAreaElevation{}, err
+	}
+	return StoAreaElevation{strings.TrimSpace(ieArray[0]), elev}, nil
+}
+
+// Parse Meteorological Boundary Condition line e.g. 'Met BC=Precipitation|Gridded DSS Pathname=/SHG/MARFC/PRECIP///NEXRAD/'
+// and add its setting to the given variables map. Unknown settings and unparsable values are recorded as other settings.
+// Returns false if the variable and setting cannot be determined
+func parseMetBC(line string, variables map[string]MetBC) bool {
+	mbcArray := strings.SplitN(line, "=", 3)
+	if len(mbcArray) < 3 || !strings.Contains(mbcArray[1], "|") {
+		return false
+	}
+	varSetting := strings.SplitN(mbcArray[1], "|", 2)
+	variable := strings.TrimSpace(varSetting[0])
+	setting := strings.TrimSpace(varSetting[1])
+	value := strings.TrimSpace(mbcArray[2])
+
+	mbc := variables[variable]
+	switch setting {
+	case "Mode":
+		mbc.Mode = value
+	case "Constant Value":
+		constVal, err := parseFloat(value, 64)
+		if err != nil {
+			mbc.setOther(setting, value)
+			break
+		}
+		mbc.ConstantValue = constVal
+	case "Constant Units":
+		mbc.ConstantUnits = value
+	case "Point Interpolation":
+		mbc.PointInterpolation = value
+	case "Gridded Source":
+		mbc.GriddedSource = value
+	case "Gridded DSS Filename":
+		mbc.DSSFile = value
+	case "Gridded DSS Pathname":
+		mbc.DSSPath = value
+	case "Gridded Raster Filename":
+		mbc.RasterFiles = value
+	default:
+		mbc.setOther(setting, value)
+	}
+	variables[variable] = mbc
+	return true
+}
+
+// Record a Met BC setting that is not parsed into its own field
+func (mbc *MetBC) setOther(setting string, value string) {
+	if mbc.OtherSettings == nil {
+		mbc.OtherSettings = make(map[string]string)
+	}
+	mbc.OtherSettings[setting] = value
+}
+
 // Get Rating Curve Boundary Condition Data
 // Returns at EOF or if new Unsteady element is encountered
 func getRatingCurveData(sc *bufio.Scanner) (rc RatingCurve, skipScan bool, err error) {
@@ -252,6 +419,164 @@ func getGateData(sc *bufio.Scanner) (gates map[string]*Hydrograph, skipScan bool
 	return
 }
 
+// Get Observed Time Series data.
+// Advances the given scanner.
+// Returns at EOF or if new Unsteady element is encountered
+func getObservedData(sc *bufio.Scanner) (obs ObservedTimeSeries, skipScan bool, err error) {
+	// e.g. 'Observed Data=Stage,USGS 01646500'
+	typeName := strings.SplitN(rightofEquals(sc.Text()), ",", 2)
+	obs.Type = strings.TrimSpace(typeName[0])
+	if len(typeName) == 2 {
+		obs.Name = strings.TrimSpace(typeName[1])
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		if stringInSlice(loe, unsteadyElementsPrefix[:]) {
+			return obs, true, nil
+		}
+
+		switch loe {
+		case "Observed Data Location":
+			// Observed Data Locations share the same header as Boundary Locations
+			locationType, location, _, bc, innerErr := parseUnsteadyBCHeader(line)
+			if innerErr != nil {
+				return obs, false, innerErr
+			}
+			obs.LocationType = locationType
+			obs.Location = location
+			obs.RS = bc.RS
+			obs.BCLine = bc.BCLine
+		case "Observed Data Gage":
+			obs.Gage = strings.TrimSpace(rightofEquals(line))
+		case "Observed Data Interval":
+			obs.TimeInterval = strings.TrimSpace(rightofEquals(line))
+		case "Observed Data Use DSS":
+			if rightofEquals(line) == "True" {
+				obs.UseDSS = true
+			}
+		case "Observed Data DSS File":
+			obs.DSSFile = strings.TrimSpace(rightofEquals(line))
+		case "Observed Data DSS Path":
+			obs.DSSPath = strings.TrimSpace(rightofEquals(line))
+		}
+	}
+	return
+}
+
+// Parse location line of Elev Controlled Gates and Navigation Dams e.g. 'Nav Hinge Point=Mississippi     ,Pool 24         ,273.4'
+func parseControlLocation(line string) string {
+	location := []string{}
+	for _, val := range strings.Split(rightofEquals(line), ",") {
+		if strings.TrimSpace(val) != "" {
+			location = append(location, strings.TrimSpace(val))
+		}
+	}
+	return strings.Join(location, " - ")
+}
+
+// Get Elev Controlled Gates data
+// Returns at EOF or if new Unsteady element is encountered
+func getElevControlledGateData(sc *bufio.Scanner) (gates map[string]*ElevControlledGate, skipScan bool, err error) {
+	gates = make(map[string]*ElevControlledGate)
+	var ecg *ElevControlledGate
+	var floatFields map[string]*float64
+
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		if stringInSlice(loe, unsteadyElementsPrefix[:]) {
+			return gates, true, nil
+		}
+
+		if loe == "Gate Name" {
+			gateName := strings.TrimSpace(rightofEquals(line))
+			// when new Gate starts, create a new variable to assign data to
+			ecg = &ElevControlledGate{}
+			gates[gateName] = ecg
+			floatFields = map[string]*float64{
+				"Gate Open Elev":    &ecg.OpenElev,
+				"Gate Close Elev":   &ecg.CloseElev,
+				"Gate Open Rate":    &ecg.OpenRate,
+				"Gate Close Rate":   &ecg.CloseRate,
+				"Gate Max Opening":  &ecg.MaxOpening,
+				"Gate Min Opening":  &ecg.MinOpening,
+				"Gate Init Opening": &ecg.InitialOpening,
+			}
+			continue
+		}
+		if ecg == nil {
+			continue
+		}
+
+		switch loe {
+		case "Gate Reference Type":
+			ecg.ReferenceType = strings.TrimSpace(rightofEquals(line))
+		case "Gate Reference Location":
+			ecg.ReferenceLocation = parseControlLocation(line)
+		case "Gate Downstream Location":
+			ecg.DownstreamLocation = parseControlLocation(line)
+		default:
+			if field, ok := floatFields[loe]; ok {
+				val, innerErr := parseFloat(strings.TrimSpace(rightofEquals(line)), 64)
+				if innerErr != nil {
+					return gates, false, innerErr
+				}
+				*field = val
+			}
+		}
+	}
+	return
+}
+
+// Get Navigation Dam data
+// Returns at EOF or if new Unsteady element is encountered
+func getNavigationDamData(sc *bufio.Scanner) (nd NavigationDam, skipScan bool, err error) {
+	floatFields := map[string]*float64{
+		"Nav Target Pool":         &nd.TargetPoolElev,
+		"Nav Min Pool":            &nd.MinPoolElev,
+		"Nav Max Pool":            &nd.MaxPoolElev,
+		"Nav Target Hinge":        &nd.TargetHingeElev,
+		"Nav Min Hinge":           &nd.MinHingeElev,
+		"Nav Max Hinge":           &nd.MaxHingeElev,
+		"Nav Max Gate Rate":       &nd.MaxGateRate,
+		"Nav Min Gate Change":     &nd.MinGateChange,
+		"Nav Flow Low Threshold":  &nd.FlowLowThreshold,
+		"Nav Flow High Threshold": &nd.FlowHighThreshold,
+		"Nav Init Opening":        &nd.InitialOpening,
+	}
+
+	for sc.Scan() {
+		line := sc.Text()
+		loe := leftofEquals(line)
+
+		if stringInSlice(loe, unsteadyElementsPrefix[:]) {
+			return nd, true, nil
+		}
+
+		switch loe {
+		case "Nav Mode":
+			nd.Mode = strings.TrimSpace(rightofEquals(line))
+		case "Nav Gate Name":
+			nd.Gates = append(nd.Gates, strings.TrimSpace(rightofEquals(line)))
+		case "Nav Hinge Point":
+			nd.HingePoint = parseControlLocation(line)
+		default:
+			if field, ok := floatFields[loe]; ok {
+				val, innerErr := parseFloat(strings.TrimSpace(rightofEquals(line)), 64)
+				if innerErr != nil {
+					return nd, false, innerErr
+				}
+				*field = val
+			}
+		}
+	}
+	return
+}
+
 // Get Boundary Condition's data.
 // Advances the given scanner.
 // Returns if new RAS element is encountered or all necessary data is obtained.
@@ -346,13 +671,40 @@ func getBoundaryCondition(sc *bufio.Scanner) (parentType string, parent string,
 			return
 
 		case "Rule Operation", "Rule Expression": // both are keywords for Rules BC
+			rules, description, ss, innerErr := getRulesData(sc)
+			skipScan = ss
+			if innerErr != nil {
+				err = innerErr
+				return
+			}
+
+			bc.Data = rules
+			bc.Description = description
 			bc.Type = "Rules"
-			bc.Data = "Not Implemented"
 			return
 
-		case "Elev Controlled Gate", "Navigation Dam":
+		case "Elev Controlled Gate":
+			gates, ss, innerErr := getElevControlledGateData(sc)
+			skipScan = ss
+			if innerErr != nil {
+				err = innerErr
+				return
+			}
+
+			bc.Data = gates
+			bc.Type = loe
+			return
+
+		case "Navigation Dam":
+			nd, ss, innerErr := getNavigationDamData(sc)
+			skipScan = ss
+			if innerErr != nil {
+				err = innerErr
+				return
+			}
+
+			bc.Data = nd
 			bc.Type = loe
-			bc.Data = "Not Implemented"
 			return
 
 		}
@@ -365,15 +717,19 @@ func getBoundaryCondition(sc *bufio.Scanner) (parentType string, parent string,
 func getUnsteadyData(fd *ForcingData, fs filestore.FileStore, flowFilePath string, mu *sync.Mutex) error {
 	flowFileName := filepath.Base(flowFilePath)
 	ud := UnsteadyData{
-		InitialConditions: "Not Implemented",
+		InitialConditions: InitialConditions{
+			Flows: make(map[string][]RSFlow),
+		},
 		BoundaryConditions: UnsteadyBoundaryConditions{
 			Reaches:      make(map[string][]BoundaryCondition),
 			Areas:        make(map[string][]BoundaryCondition),
 			Connections:  make(map[string][]BoundaryCondition),
 			PumpStations: make(map[string]BoundaryCondition), // Unlike other features a pump cannot have multiple boundary conditions
 		},
-		MeterologicalData: "Not Implemented",
-		ObservedData:      "Not Implemented",
+		MeterologicalData: MeteorologicalData{
+			Variables: make(map[string]MetBC),
+		},
+		ObservedData: []ObservedTimeSeries{},
 	}
 
 	file, err := fs.GetObject(flowFilePath)
@@ -398,6 +754,45 @@ func getUnsteadyData(fd *ForcingData, fs filestore.FileStore, flowFilePath strin
 			ud.FlowTitle = strings.TrimSpace(rightofEquals(line))
 		case "Program Version":
 			ud.ProgramVersion = strings.TrimSpace(rightofEquals(line))
+		case "Use Restart":
+			if strings.TrimSpace(rightofEquals(line)) == "-1" {
+				ud.InitialConditions.UseRestart = true
+			}
+		case "Restart Filename":
+			ud.InitialConditions.RestartFile = strings.TrimSpace(rightofEquals(line))
+		case "Initial Flow Loc":
+			reach, rsFlow, err := parseInitialFlow(line)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			ud.InitialConditions.Flows[reach] = append(ud.InitialConditions.Flows[reach], rsFlow)
+		case "Initial Storage Elev", "Initial 2DArea Elev":
+			areaElev, err := parseInitialElevation(line)
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			if loe == "Initial Storage Elev" {
+				ud.InitialConditions.StorageAreaElevations = append(ud.InitialConditions.StorageAreaElevations, areaElev)
+			} else {
+				ud.InitialConditions.TwoDAreaElevations = append(ud.InitialConditions.TwoDAreaElevations, areaElev)
+			}
+		case "Met Point Raster Parameters":
+			ud.MeterologicalData.PointRasterParameters = strings.TrimSpace(rightofEquals(line))
+		case "Precipitation Mode":
+			ud.MeterologicalData.PrecipitationMode = strings.TrimSpace(rightofEquals(line))
+		case "Wind Mode":
+			ud.MeterologicalData.WindMode = strings.TrimSpace(rightofEquals(line))
+		case "Met BC":
+			if !parseMetBC(line, ud.MeterologicalData.Variables) {
+				ud.MeterologicalData.Unparsed = append(ud.MeterologicalData.Unparsed, strings.TrimSpace(rightofEquals(line)))
+			}
+		case "Observed Data":
+			obs, ss, err := getObservedData(sc)
+			skipScan = ss
+			if err != nil {
+				return errors.Wrap(err, 0)
+			}
+			ud.ObservedData = append(ud.ObservedData, obs)
 		case "Boundary Location":
 			parentType, parent, bc, ss, err := getBoundaryCondition(sc)
 			skipScan = ss
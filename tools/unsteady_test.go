@@ -0,0 +1,183 @@
+package tools
+
+import (
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/USACE/filestore"
+)
+
+func readTestUnsteadyData(t *testing.T, fn string) UnsteadyData {
+	fs, err := filestore.NewFileStore(filestore.BlockFSConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd := ForcingData{Unsteady: make(map[string]UnsteadyData)}
+	if err := getUnsteadyData(&fd, fs, fn, &sync.Mutex{}); err != nil {
+		t.Fatal(err)
+	}
+	return fd.Unsteady[filepath.Base(fn)]
+}
+
+func TestGetUnsteadyInitialConditions(t *testing.T) {
+	ud := readTestUnsteadyData(t, "testdata/InitialConditions.u01")
+
+	want := InitialConditions{
+		UseRestart:  true,
+		RestartFile: "Muncie.p04.01JAN2020 0000.rst",
+		Flows: map[string][]RSFlow{
+			"Butte Cr. - Upper Reach": {{"5.99", 500}, {"5.5", 650.5}},
+			"Tributary - Trib":        {{"0.2", 120}},
+		},
+		StorageAreaElevations: []StoAreaElevation{{"Upper SA", 4000}},
+		TwoDAreaElevations:    []StoAreaElevation{{"Perimeter 1", 946.5}},
+	}
+	if !reflect.DeepEqual(ud.InitialConditions, want) {
+		t.Errorf("got initial conditions %+v, want %+v", ud.InitialConditions, want)
+	}
+
+	// the boundary condition following the initial conditions is still parsed
+	wantBC := []BoundaryCondition{{RS: "0.1", Type: "Normal Depth", Data: map[string]float64{"Friction Slope": 0.0005}}}
+	if got := ud.BoundaryConditions.Reaches["Butte Cr. - Lower Reach"]; !reflect.DeepEqual(got, wantBC) {
+		t.Errorf("got boundary conditions %+v, want %+v", got, wantBC)
+	}
+}
+
+func TestParseInitialFlowInvalid(t *testing.T) {
+	for _, line := range []string{
+		"Initial Flow Loc=Butte Cr.       ,Upper Reach     ,5.99",
+		"Initial Flow Loc=                ,                ,5.99    ,500",
+		"Initial Flow Loc=Butte Cr.       ,Upper Reach     ,5.99    ,five hundred",
+	} {
+		if _, _, err := parseInitialFlow(line); err == nil {
+			t.Errorf("expected an error for %q", line)
+		}
+	}
+}
+
+func TestGetUnsteadyMeteorologicalData(t *testing.T) {
+	ud := readTestUnsteadyData(t, "testdata/MetBC.u01")
+
+	want := MeteorologicalData{
+		PointRasterParameters: "2000,-100000,-50000,200,150",
+		PrecipitationMode:     "Enable",
+		WindMode:              "No Wind Forces",
+		Variables: map[string]MetBC{
+			"Precipitation": {
+				Mode:               "Gridded",
+				GriddedSource:      "DSS",
+				DSSFile:            "precip.dss",
+				DSSPath:            "/SHG/MARFC/PRECIP///NEXRAD/",
+				PointInterpolation: "Nearest",
+			},
+			"Evapotranspiration": {Mode: "Constant", ConstantValue: 0.2, ConstantUnits: "in/day"},
+			"Temperature":        {RasterFiles: "temperature.tif"},
+			// unparsable constant values and unknown settings are kept as other settings
+			"Wind Speed": {OtherSettings: map[string]string{"Constant Value": "calm", "Expanded View": "True"}},
+		},
+		Unparsed: []string{"Humidity"},
+	}
+	if !reflect.DeepEqual(ud.MeterologicalData, want) {
+		t.Errorf("got meteorological data %+v, want %+v", ud.MeterologicalData, want)
+	}
+
+	wantBC := []BoundaryCondition{{BCLine: "Upstream Inflow", Type: "Normal Depth", Data: map[string]float64{"Friction Slope": 0.001}}}
+	if got := ud.BoundaryConditions.Areas["Perimeter 1"]; !reflect.DeepEqual(got, wantBC) {
+		t.Errorf("got boundary conditions %+v, want %+v", got, wantBC)
+	}
+}
+
+func TestGetUnsteadyObservedData(t *testing.T) {
+	ud := readTestUnsteadyData(t, "testdata/ObservedData.u01")
+
+	want := []ObservedTimeSeries{
+		{
+			Name:         "USGS 03347000",
+			Type:         "Stage",
+			Gage:         "03347000",
+			LocationType: "Reach",
+			Location:     "White - Muncie",
+			RS:           "15485.51",
+			TimeInterval: "15MIN",
+			UseDSS:       true,
+			DSSFile:      "Observed.dss",
+			DSSPath:      "/WHITE/MUNCIE/STAGE//15MIN/USGS/",
+		},
+		{
+			Name:         "Perimeter Outflow",
+			Type:         "Flow",
+			LocationType: "Area",
+			Location:     "Perimeter 1",
+			BCLine:       "Outflow",
+			TimeInterval: "1HOUR",
+		},
+	}
+	if !reflect.DeepEqual(ud.ObservedData, want) {
+		t.Errorf("got observed data %+v, want %+v", ud.ObservedData, want)
+	}
+
+	if got := ud.BoundaryConditions.Reaches["White - Muncie"]; len(got) != 1 || got[0].Type != "Normal Depth" {
+		t.Errorf("got boundary conditions %+v", got)
+	}
+}
+
+func TestGetUnsteadyGateData(t *testing.T) {
+	ud := readTestUnsteadyData(t, "testdata/Gates.u01")
+
+	bcs := ud.BoundaryConditions.Reaches["White - Muncie"]
+	if len(bcs) != 2 {
+		t.Fatalf("got %d boundary conditions, want 2: %+v", len(bcs), bcs)
+	}
+	if bcs[0].Type != "Elev Controlled Gate" || bcs[1].Type != "Normal Depth" {
+		t.Errorf("got boundary condition types %q and %q", bcs[0].Type, bcs[1].Type)
+	}
+
+	wantGates := map[string]*ElevControlledGate{
+		"Left Gate": {
+			ReferenceType:     "Reference Elevation",
+			ReferenceLocation: "White - Muncie - 10500",
+			OpenElev:          945,
+			CloseElev:         940,
+			OpenRate:          0.5,
+			CloseRate:         0.25,
+			MaxOpening:        10,
+			InitialOpening:    1,
+		},
+		"Right Gate": {
+			ReferenceType:      "Elevation Difference",
+			ReferenceLocation:  "Upper SA",
+			DownstreamLocation: "White - Muncie - 9500",
+			OpenElev:           2,
+			CloseElev:          0.5,
+		},
+	}
+	if !reflect.DeepEqual(bcs[0].Data, wantGates) {
+		t.Errorf("got elev controlled gates %+v, want %+v", bcs[0].Data, wantGates)
+	}
+
+	navBCs := ud.BoundaryConditions.Reaches["Mississippi - Pool 24"]
+	if len(navBCs) != 1 || navBCs[0].Type != "Navigation Dam" {
+		t.Fatalf("got navigation dam boundary conditions %+v", navBCs)
+	}
+	wantDam := NavigationDam{
+		Mode:              "Pool and Hinge Point Control",
+		Gates:             []string{"Tainter 1", "Tainter 2"},
+		HingePoint:        "Mississippi - Pool 24 - 280.1",
+		TargetPoolElev:    449,
+		MinPoolElev:       448.5,
+		MaxPoolElev:       449.5,
+		TargetHingeElev:   445,
+		MinHingeElev:      444,
+		MaxHingeElev:      446,
+		MaxGateRate:       0.1,
+		MinGateChange:     0.05,
+		FlowLowThreshold:  10000,
+		FlowHighThreshold: 100000,
+		InitialOpening:    3,
+	}
+	if !reflect.DeepEqual(navBCs[0].Data, wantDam) {
+		t.Errorf("got navigation dam %+v, want %+v", navBCs[0].Data, wantDam)
+	}
+}
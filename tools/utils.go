@@ -206,3 +206,45 @@ func getDataPairsfromTextBlock(nDataPairsLine string, sc *bufio.Scanner, colWidt
 	}
 	return pairs, nil
 }
+
+// Check if a point falls within a polygon using ray casting
+func pointInPolygon(pt [2]float64, polygon [][2]float64) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		pi, pj := polygon[i], polygon[j]
+		if (pi[1] > pt[1]) != (pj[1] > pt[1]) && pt[0] < (pj[0]-pi[0])*(pt[1]-pi[1])/(pj[1]-pi[1])+pi[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// Check if a point falls on the boundary of a polygon, within a tolerance
+func pointOnBoundary(pt [2]float64, polygon [][2]float64, tolerance float64) bool {
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		p0, p1 := polygon[j], polygon[i]
+		dx, dy := p1[0]-p0[0], p1[1]-p0[1]
+		t := 0.0
+		if lengthSq := dx*dx + dy*dy; lengthSq > 0 {
+			t = math.Max(0, math.Min(1, ((pt[0]-p0[0])*dx+(pt[1]-p0[1])*dy)/lengthSq))
+		}
+		if math.Hypot(pt[0]-(p0[0]+t*dx), pt[1]-(p0[1]+t*dy)) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the fraction of points falling within a polygon, points on its boundary are counted as within
+func fractionInPolygon(points [][2]float64, polygon [][2]float64) float64 {
+	if len(points) == 0 || len(polygon) < 3 {
+		return 0
+	}
+	nInside := 0
+	for _, pt := range points {
+		if pointOnBoundary(pt, polygon, 1e-6) || pointInPolygon(pt, polygon) {
+			nInside++
+		}
+	}
+	return float64(nInside) / float64(len(points))
+}
@@ -0,0 +1,24 @@
+package tools
+
+import "testing"
+
+func TestFractionInPolygon(t *testing.T) {
+	square := [][2]float64{{0, 0}, {10, 0}, {10, 10}, {0, 10}}
+
+	tests := []struct {
+		name   string
+		points [][2]float64
+		want   float64
+	}{
+		{"inside", [][2]float64{{1, 1}, {5, 5}, {9, 9}}, 1},
+		{"outside", [][2]float64{{11, 1}, {-5, 5}}, 0},
+		{"on boundary", [][2]float64{{0, 5}, {10, 10}, {5, 0}}, 1},
+		{"crossing", [][2]float64{{5, 5}, {8, 5}, {15, 5}, {20, 5}}, 0.5},
+		{"empty", [][2]float64{}, 0},
+	}
+	for _, tc := range tests {
+		if got := fractionInPolygon(tc.points, square); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}